@@ -0,0 +1,42 @@
+/*
+Copyright 2020 The redhawk Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"github.com/DevopsArtFactory/redhawk/cmd/redhawk/cmd/builder"
+	"github.com/DevopsArtFactory/redhawk/pkg/executor"
+)
+
+// Audit resources with rules
+func NewCmdAudit() *cobra.Command {
+	return builder.NewCmd("audit").
+		WithDescription("audit infrastructure resources in AWS with rules").
+		SetFlags().
+		RunWithCmdAndNoArgs(funcAudit)
+}
+
+// funcAudit
+func funcAudit(ctx context.Context, out io.Writer, cmd *cobra.Command) error {
+	return executor.RunAuditExecutor(ctx, func(executor executor.Executor) error {
+		return executor.Runner.Audit(out)
+	})
+}
@@ -51,7 +51,7 @@ var FlagRegistry = []Flag{
 		Value:         aws.String(constants.EmptyString),
 		DefValue:      constants.EmptyString,
 		FlagAddMethod: "StringVar",
-		DefinedOn:     []string{"list"},
+		DefinedOn:     []string{"list", "audit"},
 	},
 	{
 		Name:          "config",
@@ -59,7 +59,7 @@ var FlagRegistry = []Flag{
 		Value:         aws.String(constants.EmptyString),
 		DefValue:      constants.EmptyString,
 		FlagAddMethod: "StringVar",
-		DefinedOn:     []string{"list"},
+		DefinedOn:     []string{"list", "audit"},
 	},
 	{
 		Name:          "detail",
@@ -76,7 +76,7 @@ var FlagRegistry = []Flag{
 		Shorthand:     "A",
 		DefValue:      false,
 		FlagAddMethod: "BoolVar",
-		DefinedOn:     []string{"list"},
+		DefinedOn:     []string{"list", "audit"},
 	},
 	{
 		Name:          "resources",
@@ -84,7 +84,39 @@ var FlagRegistry = []Flag{
 		Value:         aws.String(constants.EmptyString),
 		DefValue:      constants.EmptyString,
 		FlagAddMethod: "StringVar",
-		DefinedOn:     []string{"list"},
+		DefinedOn:     []string{"list", "audit"},
+	},
+	{
+		Name:          "profile",
+		Usage:         "Audit with rules in the profile or benchmark only(e.g. stale, cis-aws-1.5)",
+		Value:         aws.String(constants.EmptyString),
+		DefValue:      constants.EmptyString,
+		FlagAddMethod: "StringVar",
+		DefinedOn:     []string{"audit"},
+	},
+	{
+		Name:          "ignore-file",
+		Usage:         "Suppression file with waivers of findings",
+		Value:         aws.String(constants.DefaultIgnoreFile),
+		DefValue:      constants.DefaultIgnoreFile,
+		FlagAddMethod: "StringVar",
+		DefinedOn:     []string{"audit"},
+	},
+	{
+		Name:          "framework",
+		Usage:         "Group findings by controls of the compliance framework(cis, soc2, iso27001, pci)",
+		Value:         aws.String(constants.EmptyString),
+		DefValue:      constants.EmptyString,
+		FlagAddMethod: "StringVar",
+		DefinedOn:     []string{"audit"},
+	},
+	{
+		Name:          "fail-on",
+		Usage:         "Exit with error if any finding is at or above the severity(info, low, medium, high, critical)",
+		Value:         aws.String(constants.EmptyString),
+		DefValue:      constants.EmptyString,
+		FlagAddMethod: "StringVar",
+		DefinedOn:     []string{"audit"},
 	},
 	{
 		Name:          "output",
@@ -93,7 +125,7 @@ var FlagRegistry = []Flag{
 		Value:         aws.String(constants.DefaultOutputFormat),
 		DefValue:      constants.DefaultOutputFormat,
 		FlagAddMethod: "StringVar",
-		DefinedOn:     []string{"list"},
+		DefinedOn:     []string{"list", "audit"},
 	},
 }
 
@@ -70,6 +70,7 @@ func NewRootCommand(out, stderr io.Writer) *cobra.Command {
 			Message: "checking all resources in cloud provider",
 			Commands: []*cobra.Command{
 				NewCmdList(),
+				NewCmdAudit(),
 			},
 		},
 	}
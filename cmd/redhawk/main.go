@@ -33,6 +33,7 @@ func main() {
 			Logger.Debugln("ignore error since context is cancelled:", err)
 		} else {
 			color.Red.Fprintln(os.Stderr, err)
+			os.Exit(1)
 		}
 	}
 }
@@ -4,15 +4,31 @@ go 1.16
 
 require (
 	github.com/AlecAivazis/survey/v2 v2.1.1 // indirect
-	github.com/aws/aws-sdk-go-v2 v1.7.0
+	github.com/aws/aws-sdk-go-v2 v1.17.8
 	github.com/aws/aws-sdk-go-v2/config v1.4.0
-	github.com/aws/aws-sdk-go-v2/credentials v1.3.0 // indirect
+	github.com/aws/aws-sdk-go-v2/credentials v1.3.0
+	github.com/aws/aws-sdk-go-v2/service/account v1.10.4
+	github.com/aws/aws-sdk-go-v2/service/cloudfront v1.26.4
+	github.com/aws/aws-sdk-go-v2/service/cloudtrail v1.24.6
 	github.com/aws/aws-sdk-go-v2/service/ec2 v1.10.0
+	github.com/aws/aws-sdk-go-v2/service/efs v1.19.12
+	github.com/aws/aws-sdk-go-v2/service/elasticbeanstalk v1.15.8
+	github.com/aws/aws-sdk-go-v2/service/elasticloadbalancing v1.15.8
+	github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2 v1.19.9
+	github.com/aws/aws-sdk-go-v2/service/fsx v1.28.10
 	github.com/aws/aws-sdk-go-v2/service/iam v1.6.0
+	github.com/aws/aws-sdk-go-v2/service/identitystore v1.16.9
+	github.com/aws/aws-sdk-go-v2/service/kafka v1.19.10
+	github.com/aws/aws-sdk-go-v2/service/kinesis v1.17.10
+	github.com/aws/aws-sdk-go-v2/service/lambda v1.33.0
+	github.com/aws/aws-sdk-go-v2/service/organizations v1.19.4
 	github.com/aws/aws-sdk-go-v2/service/rds v1.5.0
 	github.com/aws/aws-sdk-go-v2/service/route53 v1.7.0
-	github.com/aws/aws-sdk-go-v2/service/s3 v1.11.0
+	github.com/aws/aws-sdk-go-v2/service/s3 v1.31.3
+	github.com/aws/aws-sdk-go-v2/service/s3control v1.31.3
+	github.com/aws/aws-sdk-go-v2/service/ssoadmin v1.16.8
 	github.com/aws/aws-sdk-go-v2/service/sts v1.5.0
+	github.com/aws/smithy-go v1.13.5
 	github.com/fatih/color v1.7.0
 	github.com/go-sql-driver/mysql v1.5.0 // indirect
 	github.com/gocarina/gocsv v0.0.0-20200925213129-04be9ee2e1a2 // indirect
@@ -0,0 +1,242 @@
+/*
+Copyright 2020 The redhawk Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"embed"
+	"fmt"
+	"path"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/DevopsArtFactory/redhawk/pkg/constants"
+	"github.com/DevopsArtFactory/redhawk/pkg/tools"
+)
+
+// builtinBenchmarks are compliance benchmarks shipped with redhawk
+//
+//go:embed benchmarks/*.yaml
+var builtinBenchmarks embed.FS
+
+// Status of controls in benchmarks
+const (
+	ControlPass         = "pass"
+	ControlFail         = "fail"
+	ControlNotEvaluated = "not_evaluated"
+)
+
+// Benchmark is a set of controls evaluated with rules.
+// Its ID is used as a profile of audit
+type Benchmark struct {
+	ID        string            `yaml:"id"`
+	Title     string            `yaml:"title"`
+	Framework string            `yaml:"framework"`
+	Params    map[string]string `yaml:"params,omitempty"`
+	Controls  []Control         `yaml:"controls"`
+}
+
+// Control is a requirement of benchmark. A control without rules cannot be evaluated by redhawk
+type Control struct {
+	ID    string   `yaml:"id"`
+	Title string   `yaml:"title"`
+	Rules []string `yaml:"rules,omitempty"`
+}
+
+// ControlResult is the status of a control in the report
+type ControlResult struct {
+	ID       string
+	Title    string
+	Status   string
+	Findings int
+	Note     string
+}
+
+// ControlSummary returns the number of controls per status
+func (r Report) ControlSummary() map[string]int {
+	ret := map[string]int{}
+	for _, c := range r.Controls {
+		ret[c.Status]++
+	}
+	return ret
+}
+
+// LoadBenchmark returns the built-in benchmark with the ID, or nil if no benchmark has the ID
+func LoadBenchmark(id string) (*Benchmark, error) {
+	if len(id) == 0 {
+		return nil, nil
+	}
+
+	benchmarks, err := loadBenchmarks()
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range benchmarks {
+		if benchmarks[i].ID == id {
+			return &benchmarks[i], nil
+		}
+	}
+
+	return nil, nil
+}
+
+// loadBenchmarks reads all built-in benchmarks
+func loadBenchmarks() ([]Benchmark, error) {
+	entries, err := builtinBenchmarks.ReadDir("benchmarks")
+	if err != nil {
+		return nil, err
+	}
+
+	var ret []Benchmark
+	for _, entry := range entries {
+		b, err := builtinBenchmarks.ReadFile(path.Join("benchmarks", entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		var benchmark Benchmark
+		if err := yaml.UnmarshalStrict(b, &benchmark); err != nil {
+			return nil, fmt.Errorf("built-in benchmark %s: %v", entry.Name(), err)
+		}
+
+		if !tools.IsStringInArray(benchmark.Framework, constants.Frameworks) {
+			return nil, fmt.Errorf("built-in benchmark %s: framework is not supported: %s", entry.Name(), benchmark.Framework)
+		}
+		ret = append(ret, benchmark)
+	}
+
+	return ret, nil
+}
+
+// addBenchmarkControls maps rules to controls of built-in benchmarks which use them
+func addBenchmarkControls(rules []Rule) error {
+	benchmarks, err := loadBenchmarks()
+	if err != nil {
+		return err
+	}
+
+	for _, b := range benchmarks {
+		for _, c := range b.Controls {
+			for _, id := range c.Rules {
+				for i := range rules {
+					if rules[i].ID != id || tools.IsStringInArray(c.ID, rules[i].Frameworks[b.Framework]) {
+						continue
+					}
+
+					frameworks := map[string][]string{}
+					for k, v := range rules[i].Frameworks {
+						frameworks[k] = v
+					}
+					frameworks[b.Framework] = append(append([]string{}, frameworks[b.Framework]...), c.ID)
+					rules[i].Frameworks = frameworks
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// FilterRules returns rules used by controls of the benchmark.
+// Parameters of the benchmark are applied unless they are set in configuration
+func (b *Benchmark) FilterRules(rules []Rule, configured map[string]string) ([]Rule, error) {
+	used := map[string]bool{}
+	for _, c := range b.Controls {
+		for _, id := range c.Rules {
+			used[id] = true
+		}
+	}
+
+	var ret []Rule
+	for _, r := range rules {
+		if used[r.ID] {
+			ret = append(ret, r)
+		}
+	}
+
+	params := map[string]string{}
+	for k, v := range b.Params {
+		if _, ok := configured[k]; !ok {
+			params[k] = v
+		}
+	}
+
+	return applyParams(ret, params)
+}
+
+// Evaluate returns the status of controls with findings which are not suppressed.
+// A control is not evaluated if its rule is disabled or resources of its rules are not scanned
+func (b *Benchmark) Evaluate(rules []Rule, report Report, coverage Coverage) []ControlResult {
+	byID := map[string]Rule{}
+	for _, r := range rules {
+		byID[r.ID] = r
+	}
+
+	counts := map[string]int{}
+	for _, f := range report.Findings {
+		counts[f.RuleID]++
+	}
+
+	var results []ControlResult
+	for _, c := range b.Controls {
+		result := ControlResult{
+			ID:     c.ID,
+			Title:  c.Title,
+			Status: ControlPass,
+		}
+
+		var notes []string
+		for _, id := range c.Rules {
+			r, ok := byID[id]
+			switch {
+			case !ok || r.Disabled:
+				notes = append(notes, fmt.Sprintf("%s is disabled", id))
+			case !coverage.Covers(ScannedResource(r.Resource), constants.EmptyString):
+				notes = append(notes, fmt.Sprintf("%s is not scanned", ScannedResource(r.Resource)))
+			default:
+				result.Findings += counts[id]
+			}
+		}
+
+		switch {
+		case len(c.Rules) == 0:
+			result.Status = ControlNotEvaluated
+			result.Note = "not supported by redhawk"
+		case result.Findings > 0:
+			result.Status = ControlFail
+		case len(notes) > 0:
+			result.Status = ControlNotEvaluated
+		}
+
+		if len(notes) > 0 {
+			result.Note = strings.Join(notes, ", ")
+		}
+
+		results = append(results, result)
+	}
+
+	return results
+}
+
+// ScannedResource returns the resource to scan for findings of the resource type
+func ScannedResource(resourceType string) string {
+	if scanned, ok := constants.ScannedResources[resourceType]; ok {
+		return scanned
+	}
+	return resourceType
+}
@@ -0,0 +1,84 @@
+/*
+Copyright 2020 The redhawk Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"testing"
+
+	"github.com/DevopsArtFactory/redhawk/pkg/constants"
+)
+
+func TestBuiltinBenchmarks(t *testing.T) {
+	rules, err := LoadRules(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	benchmark, err := LoadBenchmark("cis-aws-1.5")
+	if err != nil || benchmark == nil {
+		t.Fatalf("cis benchmark should exist: %v", err)
+	}
+
+	filtered, err := benchmark.FilterRules(rules, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ids := map[string]bool{}
+	for _, r := range filtered {
+		ids[r.ID] = true
+	}
+	for _, c := range benchmark.Controls {
+		for _, id := range c.Rules {
+			if !ids[id] {
+				t.Errorf("control %s uses a rule which does not exist: %s", c.ID, id)
+			}
+		}
+	}
+}
+
+func TestBenchmarkEvaluate(t *testing.T) {
+	benchmark := &Benchmark{
+		Controls: []Control{
+			{ID: "1", Title: "manual"},
+			{ID: "2", Title: "failed", Rules: []string{"R-1"}},
+			{ID: "3", Title: "passed", Rules: []string{"R-2"}},
+			{ID: "4", Title: "not scanned", Rules: []string{"R-3"}},
+			{ID: "5", Title: "disabled", Rules: []string{"R-2", "R-4"}},
+		},
+	}
+
+	rules := []Rule{
+		{ID: "R-1", Resource: constants.IAMUserResourceName},
+		{ID: "R-2", Resource: constants.S3ResourceName},
+		{ID: "R-3", Resource: constants.EFSResourceName},
+		{ID: "R-4", Resource: constants.S3ResourceName, Disabled: true},
+	}
+
+	coverage := Coverage{}
+	coverage.Add(constants.IAMResourceName, constants.DefaultRegion)
+	coverage.Add(constants.S3ResourceName, "ap-northeast-2")
+
+	report := Report{Findings: []Finding{{RuleID: "R-1"}, {RuleID: "R-1"}}}
+
+	expected := []string{ControlNotEvaluated, ControlFail, ControlPass, ControlNotEvaluated, ControlNotEvaluated}
+	for i, r := range benchmark.Evaluate(rules, report, coverage) {
+		if r.Status != expected[i] {
+			t.Errorf("control %s: expected %s, got %s (%s)", r.ID, expected[i], r.Status, r.Note)
+		}
+	}
+}
@@ -0,0 +1,228 @@
+/*
+Copyright 2020 The redhawk Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/DevopsArtFactory/redhawk/pkg/constants"
+)
+
+// Operators of conditions
+const (
+	OpEqual         = "eq"
+	OpNotEqual      = "ne"
+	OpLessThan      = "lt"
+	OpLessEqual     = "le"
+	OpGreaterThan   = "gt"
+	OpGreaterEqual  = "ge"
+	OpExists        = "exists"
+	OpNotExists     = "not_exists"
+	OpContains      = "contains"
+	OpIn            = "in"
+	OpMatches       = "matches"
+	OpOlderThanDays = "older_than_days"
+)
+
+var operators = []string{
+	OpEqual, OpNotEqual, OpLessThan, OpLessEqual, OpGreaterThan, OpGreaterEqual,
+	OpExists, OpNotExists, OpContains, OpIn, OpMatches, OpOlderThanDays,
+}
+
+// Condition compares a field of resource with a value or a parameter of rule.
+// Field is the json name of resource field.
+type Condition struct {
+	Field string      `yaml:"field"`
+	Op    string      `yaml:"op"`
+	Value interface{} `yaml:"value,omitempty"`
+	Param string      `yaml:"param,omitempty"`
+}
+
+// validate checks if the condition is well-formed
+func (c Condition) validate(params map[string]interface{}) error {
+	if len(c.Field) == 0 {
+		return fmt.Errorf("condition has no field")
+	}
+
+	known := false
+	for _, op := range operators {
+		if op == c.Op {
+			known = true
+			break
+		}
+	}
+	if !known {
+		return fmt.Errorf("operator is not supported: %s", c.Op)
+	}
+
+	if c.Op == OpExists || c.Op == OpNotExists {
+		return nil
+	}
+
+	expected, err := c.expected(params)
+	if err != nil {
+		return err
+	}
+
+	switch c.Op {
+	case OpLessThan, OpLessEqual, OpGreaterThan, OpGreaterEqual, OpOlderThanDays:
+		if _, ok := toFloat(expected); !ok {
+			return fmt.Errorf("%s needs a number: %s", c.Op, c.Field)
+		}
+	case OpIn:
+		if _, ok := expected.([]interface{}); !ok {
+			return fmt.Errorf("%s needs a list: %s", c.Op, c.Field)
+		}
+	case OpMatches:
+		if _, err := regexp.Compile(toString(expected)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// expected returns the value to compare
+func (c Condition) expected(params map[string]interface{}) (interface{}, error) {
+	if len(c.Param) == 0 {
+		return c.Value, nil
+	}
+
+	v, ok := params[c.Param]
+	if !ok {
+		return nil, fmt.Errorf("parameter does not exist: %s", c.Param)
+	}
+	return v, nil
+}
+
+// Match checks the condition with fields of a resource.
+// Comparisons never match a field which is not set, and only eq with empty string matches a field set to empty string.
+func (c Condition) Match(fields map[string]interface{}, params map[string]interface{}) (bool, error) {
+	actual, ok := fields[c.Field]
+	set := ok && actual != nil
+	if ok && isEmpty(actual) {
+		ok = false
+	}
+
+	switch c.Op {
+	case OpExists:
+		return ok, nil
+	case OpNotExists:
+		return !ok, nil
+	}
+
+	expected, err := c.expected(params)
+	if err != nil {
+		return false, err
+	}
+
+	if !ok {
+		return set && c.Op == OpEqual && isEmpty(expected), nil
+	}
+
+	switch c.Op {
+	case OpEqual:
+		return equals(actual, expected), nil
+	case OpNotEqual:
+		return !equals(actual, expected), nil
+	case OpLessThan, OpLessEqual, OpGreaterThan, OpGreaterEqual:
+		a, ok := toFloat(actual)
+		if !ok {
+			return false, nil
+		}
+		e, _ := toFloat(expected)
+		switch c.Op {
+		case OpLessThan:
+			return a < e, nil
+		case OpLessEqual:
+			return a <= e, nil
+		case OpGreaterThan:
+			return a > e, nil
+		default:
+			return a >= e, nil
+		}
+	case OpContains:
+		return strings.Contains(toString(actual), toString(expected)), nil
+	case OpIn:
+		list, _ := expected.([]interface{})
+		for _, e := range list {
+			if equals(actual, e) {
+				return true, nil
+			}
+		}
+		return false, nil
+	case OpMatches:
+		return regexp.MatchString(toString(expected), toString(actual))
+	case OpOlderThanDays:
+		t, err := time.Parse(time.RFC3339, toString(actual))
+		if err != nil {
+			return false, nil
+		}
+		days, _ := toFloat(expected)
+		return time.Since(t) > time.Duration(days*24)*time.Hour, nil
+	}
+
+	return false, fmt.Errorf("operator is not supported: %s", c.Op)
+}
+
+// isEmpty checks if a field has no value
+func isEmpty(v interface{}) bool {
+	if v == nil {
+		return true
+	}
+
+	s, ok := v.(string)
+	return ok && len(s) == 0
+}
+
+// equals compares values as numbers if possible, otherwise as strings
+func equals(a, b interface{}) bool {
+	if fa, ok := toFloat(a); ok {
+		if fb, ok := toFloat(b); ok {
+			return fa == fb
+		}
+	}
+	return toString(a) == toString(b)
+}
+
+// toFloat converts number or numeric string to float64
+func toFloat(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case int:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case float64:
+		return n, true
+	case string:
+		f, err := strconv.ParseFloat(n, 64)
+		return f, err == nil
+	}
+	return 0, false
+}
+
+// toString converts value to string
+func toString(v interface{}) string {
+	if v == nil {
+		return constants.EmptyString
+	}
+	return fmt.Sprint(v)
+}
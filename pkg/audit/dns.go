@@ -0,0 +1,242 @@
+/*
+Copyright 2020 The redhawk Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"encoding/base64"
+	"net"
+	"regexp"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+
+	"github.com/DevopsArtFactory/redhawk/pkg/constants"
+	"github.com/DevopsArtFactory/redhawk/pkg/resource"
+)
+
+var (
+	// s3WebsiteRegex matches S3 website endpoints. Bucket is empty for alias targets
+	s3WebsiteRegex = regexp.MustCompile(`^(?:(.+)\.)?s3-website[.-]([a-z0-9-]+)\.amazonaws\.com(?:\.cn)?$`)
+
+	// elbRegexes match DNS names of classic and application load balancers, and network load balancers
+	elbRegexes = []*regexp.Regexp{
+		regexp.MustCompile(`^.+\.([a-z0-9-]+)\.elb\.amazonaws\.com(?:\.cn)?$`),
+		regexp.MustCompile(`^.+\.elb\.([a-z0-9-]+)\.amazonaws\.com(?:\.cn)?$`),
+	}
+
+	// beanstalkRegex matches CNAMEs of elastic beanstalk environments. Legacy CNAMEs have no region
+	beanstalkRegex = regexp.MustCompile(`^.+?\.(?:([a-z]{2}(?:-gov)?-[a-z]+-\d)\.)?elasticbeanstalk\.com$`)
+
+	// privateNetworks are not routable from the internet
+	privateNetworks = []string{"10.0.0.0/8", "172.16.0.0/12", "192.168.0.0/16", "100.64.0.0/10", "127.0.0.0/8", "169.254.0.0/16"}
+)
+
+// Coverage is the set of regions where each resource is scanned without error
+type Coverage map[string]map[string]bool
+
+// Add marks the resource as scanned in the region
+func (c Coverage) Add(resource, region string) {
+	if _, ok := c[resource]; !ok {
+		c[resource] = map[string]bool{}
+	}
+	c[resource][region] = true
+}
+
+// Covers checks if the resource is scanned in the region. Empty region means any region
+func (c Coverage) Covers(resource, region string) bool {
+	if len(region) == 0 {
+		return len(c[resource]) > 0
+	}
+	return c[resource][region]
+}
+
+// DNSInventory is a set of DNS targets which exist in scanned accounts
+type DNSInventory struct {
+	Coverage Coverage
+
+	// names of resources by resource type
+	names map[string]map[string]bool
+}
+
+// NewDNSInventory collects buckets, elastic IPs and DNS names of load balancers, distributions and environments
+func NewDNSInventory(targets []Target, coverage Coverage) *DNSInventory {
+	inv := &DNSInventory{
+		Coverage: coverage,
+		names:    map[string]map[string]bool{},
+	}
+
+	for _, target := range targets {
+		switch r := target.Resource.(type) {
+		case resource.S3Resource:
+			inv.add(constants.S3ResourceName, r.Bucket)
+		case resource.EIPResource:
+			inv.add(constants.EIPResourceName, r.PublicIP)
+		case resource.LoadBalancerResource:
+			inv.add(constants.LoadBalancerResourceName, r.DNSName)
+		case resource.CloudFrontResource:
+			inv.add(constants.CloudFrontResourceName, r.DomainName)
+		case resource.BeanstalkResource:
+			inv.add(constants.BeanstalkResourceName, r.CNAME)
+		}
+	}
+
+	return inv
+}
+
+// add adds a name of resource to inventory
+func (d *DNSInventory) add(resourceType string, name *string) {
+	if name == nil {
+		return
+	}
+
+	if _, ok := d.names[resourceType]; !ok {
+		d.names[resourceType] = map[string]bool{}
+	}
+	d.names[resourceType][normalizeDNSName(*name)] = true
+}
+
+// Has checks if the resource exists in inventory
+func (d *DNSInventory) Has(resourceType, name string) bool {
+	return d.names[resourceType][normalizeDNSName(name)]
+}
+
+// CheckDanglingDNS checks targets of Route53 records with inventory.
+// Records which do not point at AWS resources are skipped.
+func CheckDanglingDNS(targets []Target, inventory *DNSInventory) []Target {
+	var ret []Target
+	seen := map[string]bool{}
+
+	for _, target := range targets {
+		record, ok := target.Resource.(resource.Route53Resource)
+		if !ok || record.RouteTo == nil {
+			continue
+		}
+
+		decoded, err := base64.StdEncoding.DecodeString(*record.RouteTo)
+		if err != nil {
+			continue
+		}
+
+		name := normalizeDNSName(aws.ToString(record.Name))
+		for _, value := range strings.Split(string(decoded), constants.DefaultDelimiter) {
+			r := CheckDNSRecord(name, aws.ToString(record.Type), value, aws.ToBool(record.Alias), inventory)
+			if r == nil {
+				continue
+			}
+
+			key := target.Account + "/" + name + "/" + *r.Type + "/" + *r.Target
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+
+			ret = append(ret, Target{
+				Account:  target.Account,
+				Region:   target.Region,
+				Resource: *r,
+			})
+		}
+	}
+
+	return ret
+}
+
+// CheckDNSRecord returns status of the record value, or nil if value is not an AWS resource
+func CheckDNSRecord(name, recordType, value string, alias bool, inventory *DNSInventory) *resource.DanglingDNSResource {
+	value = normalizeDNSName(value)
+
+	var targetType, targetRegion, key string
+	switch {
+	case recordType == "A" && !alias:
+		ip := net.ParseIP(value)
+		if ip == nil || ip.To4() == nil || isPrivateIP(ip) {
+			return nil
+		}
+		targetType, key = constants.EIPResourceName, value
+	case recordType != "A" && recordType != "AAAA" && recordType != "CNAME":
+		return nil
+	case strings.HasSuffix(value, ".cloudfront.net"):
+		targetType, key = constants.CloudFrontResourceName, value
+	case s3WebsiteRegex.MatchString(value):
+		m := s3WebsiteRegex.FindStringSubmatch(value)
+		targetType, targetRegion, key = constants.S3ResourceName, m[2], m[1]
+		if alias || len(key) == 0 {
+			key = name
+		}
+	case strings.HasSuffix(value, ".elasticbeanstalk.com"):
+		m := beanstalkRegex.FindStringSubmatch(value)
+		if m == nil {
+			return nil
+		}
+		targetType, targetRegion, key = constants.BeanstalkResourceName, m[1], value
+		if len(targetRegion) == 0 {
+			targetRegion = constants.DefaultRegion
+		}
+	default:
+		for _, re := range elbRegexes {
+			if m := re.FindStringSubmatch(value); m != nil {
+				targetType, targetRegion, key = constants.LoadBalancerResourceName, m[1], value
+				break
+			}
+		}
+		if len(targetType) == 0 {
+			return nil
+		}
+	}
+
+	ret := &resource.DanglingDNSResource{
+		ResourceType: aws.String(constants.DanglingDNSResourceName),
+		Name:         aws.String(name),
+		Type:         aws.String(recordType),
+		Target:       aws.String(value),
+		TargetType:   aws.String(targetType),
+		Status:       aws.String(constants.DNSStatusOK),
+	}
+
+	if len(targetRegion) > 0 {
+		ret.TargetRegion = aws.String(targetRegion)
+	}
+
+	switch {
+	case !inventory.Coverage.Covers(targetType, targetRegion):
+		ret.Status = aws.String(constants.DNSStatusUnverified)
+	case inventory.Has(targetType, key):
+	case targetType == constants.EIPResourceName:
+		ret.Status = aws.String(constants.DNSStatusUnownedIP)
+	default:
+		ret.Status = aws.String(constants.DNSStatusDangling)
+	}
+
+	return ret
+}
+
+// normalizeDNSName removes trailing dot and dualstack prefix of DNS name
+func normalizeDNSName(name string) string {
+	name = strings.TrimSuffix(strings.ToLower(strings.TrimSpace(name)), ".")
+	return strings.TrimPrefix(name, "dualstack.")
+}
+
+// isPrivateIP checks if IP is not routable from the internet
+func isPrivateIP(ip net.IP) bool {
+	for _, cidr := range privateNetworks {
+		_, network, err := net.ParseCIDR(cidr)
+		if err == nil && network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
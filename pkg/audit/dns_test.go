@@ -0,0 +1,89 @@
+/*
+Copyright 2020 The redhawk Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+
+	"github.com/DevopsArtFactory/redhawk/pkg/constants"
+	"github.com/DevopsArtFactory/redhawk/pkg/resource"
+)
+
+func TestCheckDanglingDNS(t *testing.T) {
+	coverage := Coverage{}
+	coverage.Add(constants.Route53ResourceName, constants.DefaultRegion)
+	coverage.Add(constants.S3ResourceName, constants.DefaultRegion)
+	coverage.Add(constants.EIPResourceName, constants.DefaultRegion)
+	coverage.Add(constants.LoadBalancerResourceName, constants.DefaultRegion)
+	coverage.Add(constants.CloudFrontResourceName, constants.DefaultRegion)
+
+	record := func(name, recordType string, alias bool, value string) Target {
+		return Target{
+			Account: "prod",
+			Region:  constants.DefaultRegion,
+			Resource: resource.Route53Resource{
+				ResourceType: aws.String(constants.Route53ResourceName),
+				Name:         aws.String(name),
+				Type:         aws.String(recordType),
+				Alias:        aws.Bool(alias),
+				RouteTo:      aws.String(base64.StdEncoding.EncodeToString([]byte(value))),
+			},
+		}
+	}
+
+	targets := []Target{
+		{Account: "prod", Resource: resource.S3Resource{ResourceType: aws.String(constants.S3ResourceName), Bucket: aws.String("static.example.com")}},
+		{Account: "prod", Resource: resource.EIPResource{ResourceType: aws.String(constants.EIPResourceName), PublicIP: aws.String("3.3.3.3")}},
+		{Account: "prod", Resource: resource.LoadBalancerResource{ResourceType: aws.String(constants.LoadBalancerResourceName), DNSName: aws.String("web-1.us-east-1.elb.amazonaws.com")}},
+		record("static.example.com.", "A", true, "s3-website-us-east-1.amazonaws.com."),
+		record("old.example.com.", "CNAME", false, "old.example.com.s3-website-us-east-1.amazonaws.com"),
+		record("www.example.com.", "A", true, "dualstack.web-1.us-east-1.elb.amazonaws.com."),
+		record("api.example.com.", "A", false, "3.3.3.3|4.4.4.4|10.0.0.1"),
+		record("cdn.example.com.", "CNAME", false, "d111111abcdef8.cloudfront.net"),
+		record("eu.example.com.", "CNAME", false, "web-2.eu-west-1.elb.amazonaws.com"),
+		record("app.example.com.", "CNAME", false, "app.us-east-1.elasticbeanstalk.com"),
+		record("mail.example.com.", "CNAME", false, "mail.example.org"),
+		record("example.com.", "MX", false, "10 mail.example.com"),
+	}
+
+	expected := map[string]string{
+		"static.example.com/s3-website-us-east-1.amazonaws.com":              constants.DNSStatusOK,
+		"old.example.com/old.example.com.s3-website-us-east-1.amazonaws.com": constants.DNSStatusDangling,
+		"www.example.com/web-1.us-east-1.elb.amazonaws.com":                  constants.DNSStatusOK,
+		"api.example.com/3.3.3.3":                                            constants.DNSStatusOK,
+		"api.example.com/4.4.4.4":                                            constants.DNSStatusUnownedIP,
+		"cdn.example.com/d111111abcdef8.cloudfront.net":                      constants.DNSStatusDangling,
+		"eu.example.com/web-2.eu-west-1.elb.amazonaws.com":                   constants.DNSStatusUnverified,
+		"app.example.com/app.us-east-1.elasticbeanstalk.com":                 constants.DNSStatusUnverified,
+	}
+
+	results := CheckDanglingDNS(targets, NewDNSInventory(targets, coverage))
+	if len(results) != len(expected) {
+		t.Fatalf("expected %d records, got %d", len(expected), len(results))
+	}
+
+	for _, r := range results {
+		d := r.Resource.(resource.DanglingDNSResource)
+		key := *d.Name + "/" + *d.Target
+		if status, ok := expected[key]; !ok || status != *d.Status {
+			t.Errorf("%s: expected %s, got %s", key, status, *d.Status)
+		}
+	}
+}
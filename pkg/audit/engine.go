@@ -0,0 +1,203 @@
+/*
+Copyright 2020 The redhawk Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/DevopsArtFactory/redhawk/pkg/constants"
+	"github.com/DevopsArtFactory/redhawk/pkg/resource"
+)
+
+// identifierFields are fields used as resource ID in findings, in order of preference
+var identifierFields = map[string][]string{
+	constants.EC2ResourceName:                  {"instance_id"},
+	constants.SGResourceName:                   {"id"},
+	constants.SGRuleResourceName:               {"group_id"},
+	constants.ExposureResourceName:             {"target_id"},
+	constants.Route53ResourceName:              {"name"},
+	constants.DanglingDNSResourceName:          {"name"},
+	constants.S3ResourceName:                   {"bucket"},
+	constants.RDSResourceName:                  {"rds_identifier"},
+	constants.IAMUserResourceName:              {"user_name"},
+	constants.IAMGroupResourceName:             {"group_name"},
+	constants.IAMRoleResourceName:              {"role_name"},
+	constants.IAMPolicyResourceName:            {"policy_arn", "policy_id", "policy_name"},
+	constants.IAMAccessKeyResourceName:         {"access_key_id"},
+	constants.IAMEscalationResourceName:        {"principal_name"},
+	constants.EFSResourceName:                  {"file_system_id"},
+	constants.FSxResourceName:                  {"file_system_id"},
+	constants.KinesisResourceName:              {"stream_name"},
+	constants.MSKResourceName:                  {"cluster_name"},
+	constants.AccountResourceName:              {"account_id"},
+	constants.EIPResourceName:                  {"public_ip"},
+	constants.KeyPairResourceName:              {"key_name"},
+	constants.SecretResourceName:               {"resource_id"},
+	constants.LoadBalancerResourceName:         {"name"},
+	constants.CloudTrailResourceName:           {"name"},
+	constants.VPCResourceName:                  {"vpc_id"},
+	constants.CloudFrontResourceName:           {"distribution_id"},
+	constants.BeanstalkResourceName:            {"environment_name"},
+	constants.OrganizationAccountResourceName:  {"account_id"},
+	constants.OrganizationUnitResourceName:     {"ou_id"},
+	constants.OrganizationPolicyResourceName:   {"policy_id"},
+	constants.SSOPermissionSetResourceName:     {"permission_set_arn"},
+	constants.SSOAccountAssignmentResourceName: {"principal_name", "principal_id"},
+}
+
+// regionFields are fields of resources which have their own region
+var regionFields = []string{"region", "region_name"}
+
+// Target is a scanned resource with its location
+type Target struct {
+	Account  string
+	Region   string
+	Resource resource.Resource
+}
+
+// Engine evaluates rules over resources
+type Engine struct {
+	Rules []Rule
+}
+
+// NewEngine creates an engine with enabled rules
+func NewEngine(rules []Rule) *Engine {
+	var enabled []Rule
+	for _, r := range rules {
+		if !r.Disabled {
+			enabled = append(enabled, r)
+		}
+	}
+
+	return &Engine{
+		Rules: enabled,
+	}
+}
+
+// Evaluate checks all targets with rules and returns sorted findings
+func (e *Engine) Evaluate(targets []Target) ([]Finding, error) {
+	rulesByResource := map[string][]Rule{}
+	for _, r := range e.Rules {
+		rulesByResource[r.Resource] = append(rulesByResource[r.Resource], r)
+	}
+
+	var findings []Finding
+	for _, target := range targets {
+		rt := target.Resource.GetResource()
+		rules := rulesByResource[rt]
+		if len(rules) == 0 {
+			continue
+		}
+
+		fields, err := Fields(target.Resource)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, rule := range rules {
+			matched, err := rule.Match(fields)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %v", rule.ID, err)
+			}
+
+			if !matched {
+				continue
+			}
+
+			message, err := rule.RenderMessage(fields)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %v", rule.ID, err)
+			}
+
+			findings = append(findings, Finding{
+				RuleID:       rule.ID,
+				Title:        rule.Title,
+				Severity:     rule.Severity,
+				ResourceType: rt,
+				ResourceID:   identifier(rt, fields),
+				Region:       region(target.Region, fields),
+				Account:      target.Account,
+				Message:      message,
+				Tags:         tagsOf(fields),
+			})
+		}
+	}
+	logrus.Debugf("findings from %d rules: %d", len(e.Rules), len(findings))
+
+	SortFindings(findings)
+
+	return findings, nil
+}
+
+// Fields converts a resource to a map with json names of fields
+func Fields(r resource.Resource) (map[string]interface{}, error) {
+	b, err := json.Marshal(r)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := map[string]interface{}{}
+	if err := json.Unmarshal(b, &fields); err != nil {
+		return nil, err
+	}
+
+	return fields, nil
+}
+
+// tagsOf returns tags of resource from its fields
+func tagsOf(fields map[string]interface{}) map[string]string {
+	tags, ok := fields["tags"].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+
+	ret := map[string]string{}
+	for k, v := range tags {
+		ret[k] = toString(v)
+	}
+	return ret
+}
+
+// identifier returns ID of resource for findings
+func identifier(resourceType string, fields map[string]interface{}) string {
+	for _, f := range identifierFields[resourceType] {
+		if v, ok := fields[f]; ok && !isEmpty(v) {
+			return toString(v)
+		}
+	}
+
+	for _, f := range []string{"name", "id"} {
+		if v, ok := fields[f]; ok && !isEmpty(v) {
+			return toString(v)
+		}
+	}
+
+	return constants.EmptyString
+}
+
+// region returns region of resource. Region of scan is used if resource has no region field
+func region(scanned string, fields map[string]interface{}) string {
+	for _, f := range regionFields {
+		if v, ok := fields[f]; ok && !isEmpty(v) {
+			return toString(v)
+		}
+	}
+	return scanned
+}
@@ -0,0 +1,548 @@
+/*
+Copyright 2020 The redhawk Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+
+	"github.com/DevopsArtFactory/redhawk/pkg/constants"
+	"github.com/DevopsArtFactory/redhawk/pkg/resource"
+	"github.com/DevopsArtFactory/redhawk/pkg/schema"
+)
+
+func TestEvaluate(t *testing.T) {
+	rules, err := LoadRules(&schema.Audit{
+		Rules: []schema.RuleOverride{
+			{ID: "RH-IAM-002", Severity: constants.SeverityCritical, Params: map[string]string{"max_access_key_age": "30"}},
+			{ID: "RH-S3-001", Disabled: true},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	targets := []Target{
+		{
+			Account: "prod",
+			Region:  constants.DefaultRegion,
+			Resource: resource.IAMUserResource{
+				ResourceType:     aws.String(constants.IAMUserResourceName),
+				UserName:         aws.String("alice"),
+				PasswordEnabled:  aws.Bool(true),
+				MFAActive:        aws.Bool(false),
+				AccessKeyAge:     aws.Int(45),
+				UserLastActivity: aws.Time(time.Now().AddDate(0, 0, -1)),
+			},
+		},
+		{
+			Account: "prod",
+			Region:  constants.DefaultRegion,
+			Resource: resource.S3Resource{
+				ResourceType:   aws.String(constants.S3ResourceName),
+				Bucket:         aws.String("logs"),
+				LoggingEnabled: aws.Bool(false),
+			},
+		},
+		{
+			Account: "prod",
+			Region:  constants.DefaultRegion,
+			Resource: resource.MSKResource{
+				ResourceType:          aws.String(constants.MSKResourceName),
+				ClusterName:           aws.String("events"),
+				Region:                aws.String("ap-northeast-2"),
+				PublicAccess:          aws.String("DISABLED"),
+				InTransitClientBroker: aws.String("TLS_PLAINTEXT"),
+			},
+		},
+	}
+
+	findings, err := NewEngine(rules).Evaluate(targets)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []Finding{
+		{RuleID: "RH-IAM-002", Severity: constants.SeverityCritical, ResourceID: "alice", Region: constants.DefaultRegion},
+		{RuleID: "RH-IAM-001", Severity: constants.SeverityHigh, ResourceID: "alice", Region: constants.DefaultRegion},
+		{RuleID: "RH-MSK-002", Severity: constants.SeverityMedium, ResourceID: "events", Region: "ap-northeast-2"},
+	}
+
+	if len(findings) != len(expected) {
+		t.Fatalf("expected %d findings, got %+v", len(expected), findings)
+	}
+
+	for i, e := range expected {
+		f := findings[i]
+		if f.RuleID != e.RuleID || f.Severity != e.Severity || f.ResourceID != e.ResourceID || f.Region != e.Region || f.Account != "prod" {
+			t.Errorf("finding %d: expected %+v, got %+v", i, e, f)
+		}
+	}
+
+	if findings[0].Message != "alice has an access key 45 days old" {
+		t.Errorf("unexpected message: %s", findings[0].Message)
+	}
+}
+
+func TestParseRulesInvalid(t *testing.T) {
+	tests := []struct {
+		name string
+		yaml string
+	}{
+		{
+			name: "unknown operator",
+			yaml: "rules:\n- id: X\n  severity: low\n  resource: s3\n  conditions:\n  - field: bucket\n    op: like\n    value: a\n",
+		},
+		{
+			name: "unknown severity",
+			yaml: "rules:\n- id: X\n  severity: urgent\n  resource: s3\n  conditions:\n  - field: bucket\n    op: exists\n",
+		},
+		{
+			name: "missing parameter",
+			yaml: "rules:\n- id: X\n  severity: low\n  resource: s3\n  conditions:\n  - field: bucket\n    op: gt\n    param: size\n",
+		},
+	}
+
+	for _, test := range tests {
+		if _, err := ParseRules([]byte(test.yaml)); err == nil {
+			t.Errorf("%s: expected error", test.name)
+		}
+	}
+}
+
+func TestStaleProfile(t *testing.T) {
+	rules, err := LoadRules(&schema.Audit{
+		Params: map[string]string{"max_inactive_days": "30"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rules, err = FilterProfile(rules, "stale")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, r := range rules {
+		if _, ok := r.Params["max_inactive_days"]; ok && r.Params["max_inactive_days"] != "30" {
+			t.Errorf("%s: parameter is not applied: %v", r.ID, r.Params["max_inactive_days"])
+		}
+	}
+
+	created := aws.Time(time.Now().AddDate(0, 0, -60))
+	targets := []Target{
+		{Resource: resource.EC2Resource{
+			ResourceType:   aws.String(constants.EC2ResourceName),
+			InstanceID:     aws.String("i-1"),
+			InstanceStatus: aws.String("stopped"),
+			StoppedAt:      aws.Time(time.Now().AddDate(0, 0, -45)),
+		}},
+		{Resource: resource.IAMRoleResource{
+			ResourceType: aws.String(constants.IAMRoleResourceName),
+			RoleName:     aws.String("deploy"),
+			Created:      created,
+		}},
+		{Resource: resource.IAMRoleResource{
+			ResourceType:     aws.String(constants.IAMRoleResourceName),
+			RoleName:         aws.String("app"),
+			Created:          created,
+			RoleLastActivity: aws.Time(time.Now()),
+		}},
+		{Resource: resource.IAMGroupResource{
+			ResourceType: aws.String(constants.IAMGroupResourceName),
+			GroupName:    aws.String("admins"),
+			UserCount:    aws.Int(0),
+		}},
+		{Resource: resource.IAMUserResource{
+			ResourceType:    aws.String(constants.IAMUserResourceName),
+			UserName:        aws.String("alice"),
+			PasswordEnabled: aws.Bool(true),
+			MFAActive:       aws.Bool(false),
+			UserCreated:     created,
+		}},
+	}
+
+	findings, err := NewEngine(rules).Evaluate(targets)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var ids []string
+	for _, f := range findings {
+		ids = append(ids, f.RuleID+"/"+f.ResourceID)
+	}
+
+	expected := []string{"RH-STALE-001/i-1", "RH-STALE-002/alice", "RH-STALE-003/deploy", "RH-STALE-004/admins"}
+	if strings.Join(ids, ",") != strings.Join(expected, ",") {
+		t.Errorf("expected %v, got %v", expected, ids)
+	}
+
+	if _, err := FilterProfile(rules, "unknown"); err == nil {
+		t.Error("expected error for unknown profile")
+	}
+}
+
+func TestReportThreshold(t *testing.T) {
+	report := Report{Findings: []Finding{
+		{Severity: constants.SeverityHigh},
+		{Severity: constants.SeverityMedium},
+		{Severity: constants.SeverityLow},
+		{Severity: constants.SeverityLow},
+	}}
+
+	for severity, expected := range map[string]int{
+		constants.SeverityCritical: 0,
+		constants.SeverityHigh:     1,
+		constants.SeverityMedium:   2,
+		constants.SeverityInfo:     4,
+	} {
+		if count := report.AtOrAbove(severity); count != expected {
+			t.Errorf("%s: expected %d, got %d", severity, expected, count)
+		}
+	}
+
+	summary := report.Summary()
+	if summary[0].Severity != constants.SeverityCritical || summary[3].Count != 2 {
+		t.Errorf("unexpected summary: %v", summary)
+	}
+}
+
+// builtinFindings evaluates built-in rules whose IDs start with the prefix, and returns sorted rule and resource IDs of findings
+func builtinFindings(t *testing.T, prefix string, targets []Target) []string {
+	rules, err := LoadRules(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var selected []Rule
+	for _, r := range rules {
+		if strings.HasPrefix(r.ID, prefix) {
+			selected = append(selected, r)
+		}
+	}
+
+	findings, err := NewEngine(selected).Evaluate(targets)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var ids []string
+	for _, f := range findings {
+		ids = append(ids, f.RuleID+"/"+f.ResourceID)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func TestComputeRules(t *testing.T) {
+	targets := []Target{
+		{Resource: resource.EC2Resource{
+			ResourceType:         aws.String(constants.EC2ResourceName),
+			InstanceID:           aws.String("i-imdsv1"),
+			InstanceStatus:       aws.String("running"),
+			IAMInstanceProfile:   aws.String("arn:aws:iam::111111111111:instance-profile/app"),
+			MetadataHTTPTokens:   aws.String("optional"),
+			MetadataHTTPEndpoint: aws.String("enabled"),
+		}},
+		{Resource: resource.EC2Resource{
+			ResourceType:         aws.String(constants.EC2ResourceName),
+			InstanceID:           aws.String("i-unencrypted"),
+			InstanceStatus:       aws.String("stopped"),
+			IAMInstanceProfile:   aws.String("arn:aws:iam::111111111111:instance-profile/app"),
+			MetadataHTTPTokens:   aws.String("required"),
+			MetadataHTTPEndpoint: aws.String("enabled"),
+			UnencryptedVolumes:   aws.String("vol-1(/dev/xvda)"),
+		}},
+		{Resource: resource.EC2Resource{
+			ResourceType:         aws.String(constants.EC2ResourceName),
+			InstanceID:           aws.String("i-key"),
+			InstanceStatus:       aws.String("running"),
+			KeyName:              aws.String("deploy"),
+			MetadataHTTPTokens:   aws.String("optional"),
+			MetadataHTTPEndpoint: aws.String("disabled"),
+		}},
+		{Resource: resource.EC2Resource{
+			ResourceType:       aws.String(constants.EC2ResourceName),
+			InstanceID:         aws.String("i-terminated"),
+			InstanceStatus:     aws.String("terminated"),
+			MetadataHTTPTokens: aws.String("optional"),
+		}},
+		{Resource: resource.KeyPairResource{
+			ResourceType: aws.String(constants.KeyPairResourceName),
+			KeyName:      aws.String("deploy"),
+			Instances:    aws.Int(1),
+		}},
+		{Resource: resource.KeyPairResource{
+			ResourceType: aws.String(constants.KeyPairResourceName),
+			KeyName:      aws.String("unused"),
+			Instances:    aws.Int(0),
+		}},
+	}
+
+	expected := []string{
+		"RH-EC2-001/i-imdsv1",
+		"RH-EC2-002/i-unencrypted",
+		"RH-EC2-003/i-key",
+		"RH-EC2-004/i-key",
+		"RH-EC2-005/unused",
+	}
+	if ids := builtinFindings(t, "RH-EC2-", targets); strings.Join(ids, ",") != strings.Join(expected, ",") {
+		t.Errorf("expected %v, got %v", expected, ids)
+	}
+}
+
+func TestAccountRules(t *testing.T) {
+	targets := []Target{
+		{Resource: resource.AccountResource{
+			ResourceType:          aws.String(constants.AccountResourceName),
+			AccountID:             aws.String("111111111111"),
+			RootMFAEnabled:        aws.Bool(true),
+			RootAccessKeysPresent: aws.Bool(false),
+			PasswordPolicy:        aws.Bool(true),
+			MinimumPasswordLength: aws.Int(14),
+			SecurityContact:       aws.String("security@example.com"),
+		}},
+		{Resource: resource.AccountResource{
+			ResourceType:          aws.String(constants.AccountResourceName),
+			AccountID:             aws.String("222222222222"),
+			RootMFAEnabled:        aws.Bool(false),
+			RootAccessKeysPresent: aws.Bool(true),
+			PasswordPolicy:        aws.Bool(true),
+			MinimumPasswordLength: aws.Int(8),
+			SecurityContact:       aws.String(""),
+		}},
+		{Resource: resource.AccountResource{
+			ResourceType:          aws.String(constants.AccountResourceName),
+			AccountID:             aws.String("333333333333"),
+			RootMFAEnabled:        aws.Bool(true),
+			RootAccessKeysPresent: aws.Bool(false),
+			PasswordPolicy:        aws.Bool(false),
+			SecurityContact:       aws.String("security@example.com"),
+		}},
+		// alternate contacts cannot be read
+		{Resource: resource.AccountResource{
+			ResourceType:          aws.String(constants.AccountResourceName),
+			AccountID:             aws.String("444444444444"),
+			RootMFAEnabled:        aws.Bool(true),
+			RootAccessKeysPresent: aws.Bool(false),
+			PasswordPolicy:        aws.Bool(true),
+			MinimumPasswordLength: aws.Int(14),
+		}},
+	}
+
+	expected := []string{
+		"RH-ACCOUNT-001/222222222222",
+		"RH-ACCOUNT-002/222222222222",
+		"RH-ACCOUNT-003/333333333333",
+		"RH-ACCOUNT-004/222222222222",
+		"RH-ACCOUNT-005/222222222222",
+	}
+	if ids := builtinFindings(t, "RH-ACCOUNT-", targets); strings.Join(ids, ",") != strings.Join(expected, ",") {
+		t.Errorf("expected %v, got %v", expected, ids)
+	}
+}
+
+func TestLoggingRules(t *testing.T) {
+	now := time.Now()
+	targets := []Target{
+		{Resource: resource.AccountResource{
+			ResourceType:     aws.String(constants.AccountResourceName),
+			AccountID:        aws.String("111111111111"),
+			MultiRegionTrail: aws.Bool(true),
+		}},
+		{Resource: resource.AccountResource{
+			ResourceType:     aws.String(constants.AccountResourceName),
+			AccountID:        aws.String("222222222222"),
+			MultiRegionTrail: aws.Bool(false),
+		}},
+		{Resource: resource.AccountResource{
+			ResourceType: aws.String(constants.AccountResourceName),
+			AccountID:    aws.String("333333333333"),
+		}},
+		{Resource: resource.CloudTrailResource{
+			ResourceType:                 aws.String(constants.CloudTrailResourceName),
+			Name:                         aws.String("compliant"),
+			Logging:                      aws.Bool(true),
+			LogFileValidation:            aws.Bool(true),
+			CloudWatchLogsLogGroup:       aws.String("arn:aws:logs:us-east-1:111111111111:log-group:trail"),
+			LatestCloudWatchLogsDelivery: aws.Time(now.Add(-time.Hour)),
+			KmsKeyID:                     aws.String("arn:aws:kms:us-east-1:111111111111:key/trail"),
+		}},
+		{Resource: resource.CloudTrailResource{
+			ResourceType:      aws.String(constants.CloudTrailResourceName),
+			Name:              aws.String("stopped"),
+			Logging:           aws.Bool(false),
+			LogFileValidation: aws.Bool(false),
+		}},
+		{Resource: resource.CloudTrailResource{
+			ResourceType:                 aws.String(constants.CloudTrailResourceName),
+			Name:                         aws.String("stale"),
+			Logging:                      aws.Bool(true),
+			LogFileValidation:            aws.Bool(true),
+			CloudWatchLogsLogGroup:       aws.String("arn:aws:logs:us-east-1:111111111111:log-group:stale"),
+			LatestCloudWatchLogsDelivery: aws.Time(now.AddDate(0, 0, -3)),
+			KmsKeyID:                     aws.String("arn:aws:kms:us-east-1:111111111111:key/trail"),
+		}},
+		{Resource: resource.S3Resource{
+			ResourceType:     aws.String(constants.S3ResourceName),
+			Bucket:           aws.String("trail-public"),
+			Access:           aws.String("public"),
+			LoggingEnabled:   aws.Bool(false),
+			CloudTrailBucket: aws.Bool(true),
+		}},
+		{Resource: resource.S3Resource{
+			ResourceType:     aws.String(constants.S3ResourceName),
+			Bucket:           aws.String("trail-private"),
+			Access:           aws.String("private"),
+			LoggingEnabled:   aws.Bool(true),
+			CloudTrailBucket: aws.Bool(true),
+		}},
+		{Resource: resource.S3Resource{
+			ResourceType:     aws.String(constants.S3ResourceName),
+			Bucket:           aws.String("website"),
+			Access:           aws.String("public"),
+			LoggingEnabled:   aws.Bool(false),
+			CloudTrailBucket: aws.Bool(false),
+		}},
+		{Resource: resource.VPCResource{
+			ResourceType:    aws.String(constants.VPCResourceName),
+			VpcID:           aws.String("vpc-logged"),
+			FlowLogsEnabled: aws.Bool(true),
+		}},
+		{Resource: resource.VPCResource{
+			ResourceType:    aws.String(constants.VPCResourceName),
+			VpcID:           aws.String("vpc-unlogged"),
+			FlowLogsEnabled: aws.Bool(false),
+		}},
+		{Resource: resource.LoadBalancerResource{
+			ResourceType:      aws.String(constants.LoadBalancerResourceName),
+			Name:              aws.String("logged"),
+			AccessLogsEnabled: aws.Bool(true),
+		}},
+		{Resource: resource.LoadBalancerResource{
+			ResourceType:      aws.String(constants.LoadBalancerResourceName),
+			Name:              aws.String("unlogged"),
+			AccessLogsEnabled: aws.Bool(false),
+		}},
+	}
+
+	var ids []string
+	for _, prefix := range []string{"RH-TRAIL-", "RH-VPC-", "RH-ELB-"} {
+		ids = append(ids, builtinFindings(t, prefix, targets)...)
+	}
+
+	expected := []string{
+		"RH-TRAIL-001/222222222222",
+		"RH-TRAIL-002/stopped",
+		"RH-TRAIL-003/stopped",
+		"RH-TRAIL-004/trail-public",
+		"RH-TRAIL-005/stopped",
+		"RH-TRAIL-006/stale",
+		"RH-TRAIL-007/trail-public",
+		"RH-TRAIL-008/stopped",
+		"RH-VPC-001/vpc-unlogged",
+		"RH-ELB-001/unlogged",
+	}
+	if strings.Join(ids, ",") != strings.Join(expected, ",") {
+		t.Errorf("expected %v, got %v", expected, ids)
+	}
+}
+
+func TestStorageAndStreamingRules(t *testing.T) {
+	targets := []Target{
+		{Resource: resource.EFSResource{
+			ResourceType: aws.String(constants.EFSResourceName),
+			FileSystemID: aws.String("fs-compliant"),
+			Encrypted:    aws.Bool(true),
+			BackupPolicy: aws.String("ENABLED"),
+		}},
+		{Resource: resource.EFSResource{
+			ResourceType: aws.String(constants.EFSResourceName),
+			FileSystemID: aws.String("fs-plain"),
+			Encrypted:    aws.Bool(false),
+			BackupPolicy: aws.String("DISABLED"),
+		}},
+		{Resource: resource.FSxResource{
+			ResourceType:        aws.String(constants.FSxResourceName),
+			FileSystemID:        aws.String("fs-backup"),
+			BackupRetentionDays: aws.Int(7),
+		}},
+		{Resource: resource.FSxResource{
+			ResourceType:        aws.String(constants.FSxResourceName),
+			FileSystemID:        aws.String("fs-nobackup"),
+			BackupRetentionDays: aws.Int(0),
+		}},
+		{Resource: resource.KinesisResource{
+			ResourceType:   aws.String(constants.KinesisResourceName),
+			StreamName:     aws.String("encrypted"),
+			EncryptionType: aws.String("KMS"),
+		}},
+		{Resource: resource.KinesisResource{
+			ResourceType:   aws.String(constants.KinesisResourceName),
+			StreamName:     aws.String("plain"),
+			EncryptionType: aws.String("NONE"),
+		}},
+		{Resource: resource.MSKResource{
+			ResourceType:          aws.String(constants.MSKResourceName),
+			ClusterName:           aws.String("private"),
+			PublicAccess:          aws.String("DISABLED"),
+			InTransitClientBroker: aws.String("TLS"),
+		}},
+		{Resource: resource.MSKResource{
+			ResourceType:          aws.String(constants.MSKResourceName),
+			ClusterName:           aws.String("public"),
+			PublicAccess:          aws.String("SERVICE_PROVIDED_EIPS"),
+			InTransitClientBroker: aws.String("TLS_PLAINTEXT"),
+		}},
+		{Resource: resource.S3Resource{
+			ResourceType: aws.String(constants.S3ResourceName),
+			Bucket:       aws.String("mfa-delete"),
+			MFADelete:    aws.Bool(true),
+		}},
+		{Resource: resource.S3Resource{
+			ResourceType: aws.String(constants.S3ResourceName),
+			Bucket:       aws.String("no-mfa-delete"),
+			MFADelete:    aws.Bool(false),
+		}},
+		{Resource: resource.S3Resource{
+			ResourceType: aws.String(constants.S3ResourceName),
+			Bucket:       aws.String("unknown-versioning"),
+		}},
+	}
+
+	var ids []string
+	for _, prefix := range []string{"RH-EFS-", "RH-FSX-", "RH-KINESIS-", "RH-MSK-", "RH-S3-009"} {
+		ids = append(ids, builtinFindings(t, prefix, targets)...)
+	}
+
+	expected := []string{
+		"RH-EFS-001/fs-plain",
+		"RH-EFS-002/fs-plain",
+		"RH-FSX-001/fs-nobackup",
+		"RH-KINESIS-001/plain",
+		"RH-MSK-001/public",
+		"RH-MSK-002/public",
+		"RH-S3-009/no-mfa-delete",
+	}
+	if strings.Join(ids, ",") != strings.Join(expected, ",") {
+		t.Errorf("expected %v, got %v", expected, ids)
+	}
+}
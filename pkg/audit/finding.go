@@ -0,0 +1,115 @@
+/*
+Copyright 2020 The redhawk Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"sort"
+
+	"github.com/DevopsArtFactory/redhawk/pkg/constants"
+)
+
+// Finding is a resource which violates a rule
+type Finding struct {
+	RuleID       string `json:"rule_id"`
+	Title        string `json:"title"`
+	Severity     string `json:"severity"`
+	ResourceType string `json:"resource_type"`
+	ResourceID   string `json:"resource_id"`
+	Region       string `json:"region"`
+	Account      string `json:"account"`
+	Message      string `json:"message"`
+
+	// Tags of the resource are used to match waivers
+	Tags map[string]string `json:"-"`
+}
+
+// Report is the result of audit.
+// Suppressed findings are accepted by waivers and only counted in the summary
+type Report struct {
+	Findings   []Finding
+	Suppressed []Finding
+
+	// Status of controls if a benchmark is audited
+	Benchmark string
+	Controls  []ControlResult
+
+	// Findings by controls if a framework is specified
+	Framework string
+	ByControl []ControlFinding
+}
+
+// SeverityCount is the number of findings of a severity
+type SeverityCount struct {
+	Severity string
+	Count    int
+}
+
+// Summary returns the number of findings per severity from the highest severity
+func (r Report) Summary() []SeverityCount {
+	var ret []SeverityCount
+	for i := len(constants.Severities) - 1; i >= 0; i-- {
+		sc := SeverityCount{Severity: constants.Severities[i]}
+		for _, f := range r.Findings {
+			if f.Severity == sc.Severity {
+				sc.Count++
+			}
+		}
+		ret = append(ret, sc)
+	}
+	return ret
+}
+
+// AtOrAbove returns the number of findings at or above the severity
+func (r Report) AtOrAbove(severity string) int {
+	count := 0
+	for _, f := range r.Findings {
+		if SeverityRank(f.Severity) >= SeverityRank(severity) {
+			count++
+		}
+	}
+	return count
+}
+
+// SeverityRank returns the order of severity. Unknown severity returns -1
+func SeverityRank(severity string) int {
+	for i, s := range constants.Severities {
+		if s == severity {
+			return i
+		}
+	}
+	return -1
+}
+
+// SortFindings sorts findings by severity, account, rule and resource
+func SortFindings(findings []Finding) {
+	sort.SliceStable(findings, func(i, j int) bool {
+		a, b := findings[i], findings[j]
+		if a.Severity != b.Severity {
+			return SeverityRank(a.Severity) > SeverityRank(b.Severity)
+		}
+		if a.Account != b.Account {
+			return a.Account < b.Account
+		}
+		if a.RuleID != b.RuleID {
+			return a.RuleID < b.RuleID
+		}
+		if a.Region != b.Region {
+			return a.Region < b.Region
+		}
+		return a.ResourceID < b.ResourceID
+	})
+}
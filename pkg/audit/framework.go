@@ -0,0 +1,93 @@
+/*
+Copyright 2020 The redhawk Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+)
+
+// UnmappedControl is the control of findings whose rules are not mapped to the framework
+const UnmappedControl = "-"
+
+// controlPart splits control IDs into numbers and words for natural order
+var controlPart = regexp.MustCompile(`[0-9]+|[A-Za-z]+`)
+
+// ControlFinding is a finding under a control of a compliance framework
+type ControlFinding struct {
+	Control string
+	Finding
+}
+
+// GroupByControl returns findings by controls of the framework which their rules are mapped to.
+// A finding appears under every control of its rule
+func GroupByControl(findings []Finding, rules []Rule, framework string) []ControlFinding {
+	controls := map[string][]string{}
+	for _, r := range rules {
+		controls[r.ID] = r.Frameworks[framework]
+	}
+
+	var ret []ControlFinding
+	for _, f := range findings {
+		mapped := controls[f.RuleID]
+		if len(mapped) == 0 {
+			mapped = []string{UnmappedControl}
+		}
+
+		for _, c := range mapped {
+			ret = append(ret, ControlFinding{Control: c, Finding: f})
+		}
+	}
+
+	// findings are already sorted by severity in a control
+	sort.SliceStable(ret, func(i, j int) bool {
+		return lessControl(ret[i].Control, ret[j].Control)
+	})
+
+	return ret
+}
+
+// lessControl compares control IDs in natural order, e.g. 1.4 < 1.10 and CC6.1 < CC6.6.
+// Unmapped findings come last
+func lessControl(a, b string) bool {
+	if a == b {
+		return false
+	}
+	if a == UnmappedControl || b == UnmappedControl {
+		return b == UnmappedControl
+	}
+
+	pa, pb := controlPart.FindAllString(a, -1), controlPart.FindAllString(b, -1)
+	for i := 0; i < len(pa) && i < len(pb); i++ {
+		if pa[i] == pb[i] {
+			continue
+		}
+
+		na, errA := strconv.Atoi(pa[i])
+		nb, errB := strconv.Atoi(pb[i])
+		if errA == nil && errB == nil {
+			return na < nb
+		}
+		return pa[i] < pb[i]
+	}
+
+	if len(pa) != len(pb) {
+		return len(pa) < len(pb)
+	}
+	return a < b
+}
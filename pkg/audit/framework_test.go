@@ -0,0 +1,69 @@
+/*
+Copyright 2020 The redhawk Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"testing"
+
+	"github.com/DevopsArtFactory/redhawk/pkg/constants"
+	"github.com/DevopsArtFactory/redhawk/pkg/tools"
+)
+
+func TestGroupByControl(t *testing.T) {
+	rules := []Rule{
+		{ID: "R-1", Frameworks: map[string][]string{constants.FrameworkCIS: {"1.10", "1.4"}}},
+		{ID: "R-2", Frameworks: map[string][]string{constants.FrameworkCIS: {"1.8"}}},
+		{ID: "R-3", Frameworks: map[string][]string{constants.FrameworkSOC2: {"CC6.1"}}},
+	}
+
+	findings := []Finding{
+		{RuleID: "R-3", ResourceID: "a"},
+		{RuleID: "R-1", ResourceID: "b"},
+		{RuleID: "R-2", ResourceID: "c"},
+		{RuleID: TagMissingRuleID, ResourceID: "d"},
+	}
+
+	expected := []string{"1.4/b", "1.8/c", "1.10/b", "-/a", "-/d"}
+
+	grouped := GroupByControl(findings, rules, constants.FrameworkCIS)
+	if len(grouped) != len(expected) {
+		t.Fatalf("expected %d findings, got %d", len(expected), len(grouped))
+	}
+
+	for i, g := range grouped {
+		if actual := g.Control + "/" + g.ResourceID; actual != expected[i] {
+			t.Errorf("%d: expected %s, got %s", i, expected[i], actual)
+		}
+	}
+}
+
+func TestBuiltinFrameworks(t *testing.T) {
+	rules, err := LoadRules(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, r := range rules {
+		if len(r.Frameworks[constants.FrameworkSOC2]) == 0 || len(r.Frameworks[constants.FrameworkISO27001]) == 0 {
+			t.Errorf("built-in rule is not mapped to frameworks: %s", r.ID)
+		}
+
+		if r.ID == "RH-IAM-001" && !tools.IsStringInArray("1.10", r.Frameworks[constants.FrameworkCIS]) {
+			t.Errorf("controls of benchmarks should be added to rules: %v", r.Frameworks)
+		}
+	}
+}
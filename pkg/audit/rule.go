@@ -0,0 +1,330 @@
+/*
+Copyright 2020 The redhawk Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"io/ioutil"
+	"path"
+	"text/template"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/DevopsArtFactory/redhawk/pkg/constants"
+	"github.com/DevopsArtFactory/redhawk/pkg/schema"
+	"github.com/DevopsArtFactory/redhawk/pkg/tools"
+)
+
+// builtinRules is the rule pack shipped with redhawk
+//
+//go:embed rules/*.yaml
+var builtinRules embed.FS
+
+// RuleSet is the format of rule files
+type RuleSet struct {
+	Rules []Rule `yaml:"rules"`
+}
+
+// Rule is a declarative check over fields of a resource.
+// A resource violates the rule if all of `conditions` and at least one of `any` match.
+type Rule struct {
+	ID          string                 `yaml:"id"`
+	Title       string                 `yaml:"title"`
+	Severity    string                 `yaml:"severity"`
+	Resource    string                 `yaml:"resource"`
+	Description string                 `yaml:"description,omitempty"`
+	Conditions  []Condition            `yaml:"conditions,omitempty"`
+	Any         []Condition            `yaml:"any,omitempty"`
+	Message     string                 `yaml:"message"`
+	Params      map[string]interface{} `yaml:"params,omitempty"`
+	Frameworks  map[string][]string    `yaml:"frameworks,omitempty"`
+	Profiles    []string               `yaml:"profiles,omitempty"`
+	Disabled    bool                   `yaml:"disabled,omitempty"`
+
+	message *template.Template
+}
+
+// Validate checks if the rule is well-formed
+func (r *Rule) Validate() error {
+	if len(r.ID) == 0 {
+		return fmt.Errorf("rule has no id: %s", r.Title)
+	}
+
+	if SeverityRank(r.Severity) < 0 {
+		return fmt.Errorf("severity is not supported: %s / %s", r.ID, r.Severity)
+	}
+
+	if len(r.Resource) == 0 {
+		return fmt.Errorf("rule has no resource: %s", r.ID)
+	}
+
+	if len(r.Conditions) == 0 && len(r.Any) == 0 {
+		return fmt.Errorf("rule has no condition: %s", r.ID)
+	}
+
+	for _, c := range append(append([]Condition{}, r.Conditions...), r.Any...) {
+		if err := c.validate(r.Params); err != nil {
+			return fmt.Errorf("%s: %v", r.ID, err)
+		}
+	}
+
+	for framework := range r.Frameworks {
+		if !tools.IsStringInArray(framework, constants.Frameworks) {
+			return fmt.Errorf("%s: framework is not supported: %s", r.ID, framework)
+		}
+	}
+
+	t, err := template.New(r.ID).Parse(r.Message)
+	if err != nil {
+		return fmt.Errorf("%s: invalid message: %v", r.ID, err)
+	}
+	r.message = t
+
+	return nil
+}
+
+// Match checks if fields of a resource violate the rule
+func (r Rule) Match(fields map[string]interface{}) (bool, error) {
+	for _, c := range r.Conditions {
+		matched, err := c.Match(fields, r.Params)
+		if err != nil || !matched {
+			return false, err
+		}
+	}
+
+	if len(r.Any) == 0 {
+		return true, nil
+	}
+
+	for _, c := range r.Any {
+		matched, err := c.Match(fields, r.Params)
+		if err != nil {
+			return false, err
+		}
+
+		if matched {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// RenderMessage makes a message of finding with fields of a resource
+func (r Rule) RenderMessage(fields map[string]interface{}) (string, error) {
+	if r.message == nil {
+		return r.Title, nil
+	}
+
+	data := map[string]interface{}{}
+	for k, v := range fields {
+		data[k] = v
+	}
+	data["params"] = r.Params
+
+	var buf bytes.Buffer
+	if err := r.message.Execute(&buf, data); err != nil {
+		return constants.EmptyString, err
+	}
+
+	if buf.Len() == 0 {
+		return r.Title, nil
+	}
+	return buf.String(), nil
+}
+
+// LoadRules reads built-in rules and custom rules, and applies overrides in configuration
+func LoadRules(config *schema.Audit) ([]Rule, error) {
+	var rules []Rule
+
+	entries, err := builtinRules.ReadDir("rules")
+	if err != nil {
+		return nil, err
+	}
+
+	for _, entry := range entries {
+		b, err := builtinRules.ReadFile(path.Join("rules", entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		parsed, err := ParseRules(b)
+		if err != nil {
+			return nil, fmt.Errorf("built-in rule file %s: %v", entry.Name(), err)
+		}
+		rules = mergeRules(rules, parsed)
+	}
+
+	if err := addBenchmarkControls(rules); err != nil {
+		return nil, err
+	}
+
+	if config == nil {
+		return rules, nil
+	}
+
+	for _, file := range config.RuleFiles {
+		b, err := ioutil.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+
+		parsed, err := ParseRules(b)
+		if err != nil {
+			return nil, fmt.Errorf("rule file %s: %v", file, err)
+		}
+		rules = mergeRules(rules, parsed)
+	}
+
+	rules, err = applyParams(rules, config.Params)
+	if err != nil {
+		return nil, err
+	}
+
+	return applyOverrides(rules, config.Rules)
+}
+
+// FilterProfile returns rules in the profile. All rules are returned if no profile is specified
+func FilterProfile(rules []Rule, profile string) ([]Rule, error) {
+	if len(profile) == 0 {
+		return rules, nil
+	}
+
+	var ret []Rule
+	for _, r := range rules {
+		if tools.IsStringInArray(profile, r.Profiles) {
+			ret = append(ret, r)
+		}
+	}
+
+	if len(ret) == 0 {
+		return nil, fmt.Errorf("no rule exists in the profile: %s", profile)
+	}
+	return ret, nil
+}
+
+// ParseRules parses and validates rules in YAML
+func ParseRules(b []byte) ([]Rule, error) {
+	var rs RuleSet
+	if err := yaml.UnmarshalStrict(b, &rs); err != nil {
+		return nil, err
+	}
+
+	for i := range rs.Rules {
+		if err := rs.Rules[i].Validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	return rs.Rules, nil
+}
+
+// mergeRules adds rules to the list. A rule with an existing ID replaces the old one
+func mergeRules(rules, added []Rule) []Rule {
+	for _, a := range added {
+		replaced := false
+		for i := range rules {
+			if rules[i].ID == a.ID {
+				rules[i] = a
+				replaced = true
+				break
+			}
+		}
+
+		if !replaced {
+			rules = append(rules, a)
+		}
+	}
+	return rules
+}
+
+// applyParams sets parameters of configuration to all rules which have them
+func applyParams(rules []Rule, params map[string]string) ([]Rule, error) {
+	for key, value := range params {
+		found := false
+		for i := range rules {
+			if _, ok := rules[i].Params[key]; !ok {
+				continue
+			}
+			found = true
+
+			updated := map[string]interface{}{}
+			for k, v := range rules[i].Params {
+				updated[k] = v
+			}
+			updated[key] = value
+			rules[i].Params = updated
+
+			if err := rules[i].Validate(); err != nil {
+				return nil, err
+			}
+		}
+
+		if !found {
+			return nil, fmt.Errorf("parameter is not used by any rule: %s", key)
+		}
+	}
+
+	return rules, nil
+}
+
+// applyOverrides applies overrides of configuration to rules
+func applyOverrides(rules []Rule, overrides []schema.RuleOverride) ([]Rule, error) {
+	for _, o := range overrides {
+		found := false
+		for i := range rules {
+			if rules[i].ID != o.ID {
+				continue
+			}
+			found = true
+
+			if o.Disabled {
+				rules[i].Disabled = true
+			}
+
+			if len(o.Severity) > 0 {
+				if SeverityRank(o.Severity) < 0 {
+					return nil, fmt.Errorf("severity is not supported: %s / %s", o.ID, o.Severity)
+				}
+				rules[i].Severity = o.Severity
+			}
+
+			if len(o.Params) > 0 {
+				params := map[string]interface{}{}
+				for k, v := range rules[i].Params {
+					params[k] = v
+				}
+				for k, v := range o.Params {
+					params[k] = v
+				}
+				rules[i].Params = params
+
+				if err := rules[i].Validate(); err != nil {
+					return nil, err
+				}
+			}
+		}
+
+		if !found {
+			return nil, fmt.Errorf("rule does not exist: %s", o.ID)
+		}
+	}
+
+	return rules, nil
+}
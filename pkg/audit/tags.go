@@ -0,0 +1,162 @@
+/*
+Copyright 2020 The redhawk Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/DevopsArtFactory/redhawk/pkg/constants"
+	"github.com/DevopsArtFactory/redhawk/pkg/schema"
+	"github.com/DevopsArtFactory/redhawk/pkg/tools"
+)
+
+// Rules of tag policy findings
+const (
+	TagMissingRuleID = "RH-TAG-001"
+	TagInvalidRuleID = "RH-TAG-002"
+
+	tagMissingTitle = "Required tag is missing"
+	tagInvalidTitle = "Tag value is not allowed"
+
+	// tagsUnknownField is set for resources whose tags cannot be read
+	tagsUnknownField = "tags_unknown"
+)
+
+// TagChecker checks tags of resources with tag policies
+type TagChecker struct {
+	policies []schema.TagPolicy
+	patterns map[string]*regexp.Regexp
+}
+
+// NewTagChecker validates tag policies and creates a checker
+func NewTagChecker(policies []schema.TagPolicy) (*TagChecker, error) {
+	c := &TagChecker{
+		patterns: map[string]*regexp.Regexp{},
+	}
+
+	for _, p := range policies {
+		for _, r := range p.Resources {
+			if !tools.IsStringInArray(r, constants.TaggableResources) {
+				return nil, fmt.Errorf("tag policy is not supported for resource: %s", r)
+			}
+		}
+
+		if len(p.Severity) == 0 {
+			p.Severity = constants.SeverityLow
+		} else if SeverityRank(p.Severity) < 0 {
+			return nil, fmt.Errorf("severity of tag policy is not supported: %s", p.Severity)
+		}
+
+		if len(p.Tags) == 0 {
+			return nil, fmt.Errorf("tag policy has no tag")
+		}
+
+		for _, t := range p.Tags {
+			if len(t.Key) == 0 {
+				return nil, fmt.Errorf("required tag has no key")
+			}
+
+			if len(t.Pattern) > 0 {
+				re, err := regexp.Compile(t.Pattern)
+				if err != nil {
+					return nil, fmt.Errorf("invalid pattern of tag %s: %v", t.Key, err)
+				}
+				c.patterns[t.Pattern] = re
+			}
+		}
+
+		c.policies = append(c.policies, p)
+	}
+
+	return c, nil
+}
+
+// Check returns findings of resources which break tag policies
+func (c *TagChecker) Check(targets []Target) ([]Finding, error) {
+	var findings []Finding
+	for _, target := range targets {
+		rt := target.Resource.GetResource()
+		if !tools.IsStringInArray(rt, constants.TaggableResources) {
+			continue
+		}
+
+		var fields map[string]interface{}
+		for _, p := range c.policies {
+			if len(p.Resources) > 0 && !tools.IsStringInArray(rt, p.Resources) {
+				continue
+			}
+
+			if fields == nil {
+				var err error
+				if fields, err = Fields(target.Resource); err != nil {
+					return nil, err
+				}
+			}
+
+			// Tags which cannot be read are not reported as missing
+			if unknown, _ := fields[tagsUnknownField].(bool); unknown {
+				break
+			}
+
+			tags := tagsOf(fields)
+			for _, t := range p.Tags {
+				finding := Finding{
+					Severity:     p.Severity,
+					ResourceType: rt,
+					ResourceID:   identifier(rt, fields),
+					Region:       region(target.Region, fields),
+					Account:      target.Account,
+					Tags:         tags,
+				}
+
+				value, ok := tags[t.Key]
+				if !ok {
+					finding.RuleID, finding.Title = TagMissingRuleID, tagMissingTitle
+					finding.Message = fmt.Sprintf("%s %s has no tag %s", rt, finding.ResourceID, t.Key)
+					findings = append(findings, finding)
+					continue
+				}
+
+				if reason := c.invalidReason(t, value); len(reason) > 0 {
+					finding.RuleID, finding.Title = TagInvalidRuleID, tagInvalidTitle
+					finding.Message = fmt.Sprintf("tag %s of %s %s is '%s', %s", t.Key, rt, finding.ResourceID, value, reason)
+					findings = append(findings, finding)
+				}
+			}
+		}
+	}
+
+	return findings, nil
+}
+
+// invalidReason returns why the value is not allowed, or empty string if the value is valid
+func (c *TagChecker) invalidReason(t schema.RequiredTag, value string) string {
+	if len(t.Values) > 0 && !tools.IsStringInArray(value, t.Values) {
+		allowed := append([]string{}, t.Values...)
+		sort.Strings(allowed)
+		return fmt.Sprintf("allowed values are %s", strings.Join(allowed, constants.DefaultDelimiter))
+	}
+
+	if re, ok := c.patterns[t.Pattern]; ok && !re.MatchString(value) {
+		return fmt.Sprintf("value does not match %s", t.Pattern)
+	}
+
+	return constants.EmptyString
+}
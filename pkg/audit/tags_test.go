@@ -0,0 +1,98 @@
+/*
+Copyright 2020 The redhawk Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+
+	"github.com/DevopsArtFactory/redhawk/pkg/constants"
+	"github.com/DevopsArtFactory/redhawk/pkg/resource"
+	"github.com/DevopsArtFactory/redhawk/pkg/schema"
+)
+
+func TestTagChecker(t *testing.T) {
+	checker, err := NewTagChecker([]schema.TagPolicy{
+		{
+			Tags: []schema.RequiredTag{{Key: "Owner"}},
+		},
+		{
+			Resources: []string{constants.EC2ResourceName},
+			Severity:  constants.SeverityMedium,
+			Tags: []schema.RequiredTag{
+				{Key: "Environment", Values: []string{"prod", "dev"}},
+				{Key: "CostCenter", Pattern: `^\d{4}$`},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	targets := []Target{
+		{Account: "prod", Resource: resource.EC2Resource{
+			ResourceType: aws.String(constants.EC2ResourceName),
+			InstanceID:   aws.String("i-1"),
+			Tags:         resource.Tags{"Owner": "team", "Environment": "test", "CostCenter": "12"},
+		}},
+		{Account: "prod", Resource: resource.S3Resource{
+			ResourceType: aws.String(constants.S3ResourceName),
+			Bucket:       aws.String("logs"),
+		}},
+		{Account: "prod", Resource: resource.S3Resource{
+			ResourceType: aws.String(constants.S3ResourceName),
+			Bucket:       aws.String("unreadable"),
+			TagsUnknown:  aws.Bool(true),
+		}},
+		{Account: "prod", Resource: resource.KinesisResource{
+			ResourceType: aws.String(constants.KinesisResourceName),
+			StreamName:   aws.String("events"),
+		}},
+	}
+
+	findings, err := checker.Check(targets)
+	if err != nil {
+		t.Fatal(err)
+	}
+	SortFindings(findings)
+
+	expected := []struct {
+		ruleID     string
+		resourceID string
+		severity   string
+	}{
+		{TagInvalidRuleID, "i-1", constants.SeverityMedium},
+		{TagInvalidRuleID, "i-1", constants.SeverityMedium},
+		{TagMissingRuleID, "logs", constants.SeverityLow},
+	}
+
+	if len(findings) != len(expected) {
+		t.Fatalf("expected %d findings, got %+v", len(expected), findings)
+	}
+
+	for i, e := range expected {
+		f := findings[i]
+		if f.RuleID != e.ruleID || f.ResourceID != e.resourceID || f.Severity != e.severity {
+			t.Errorf("finding %d: expected %+v, got %+v", i, e, f)
+		}
+	}
+
+	if _, err := NewTagChecker([]schema.TagPolicy{{Resources: []string{constants.KinesisResourceName}, Tags: []schema.RequiredTag{{Key: "Owner"}}}}); err == nil {
+		t.Error("expected error for resource without tags")
+	}
+}
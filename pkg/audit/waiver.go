@@ -0,0 +1,212 @@
+/*
+Copyright 2020 The redhawk Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"fmt"
+	"io/ioutil"
+	"regexp"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/DevopsArtFactory/redhawk/pkg/constants"
+)
+
+// Rule of expired waivers
+const (
+	WaiverExpiredRuleID = "RH-WAIVER-001"
+
+	waiverExpiredTitle = "Waiver is expired"
+	waiverResourceType = "waiver"
+	waiverDateLayout   = "2006-01-02"
+)
+
+// WaiverFile is the format of the suppression file
+type WaiverFile struct {
+	Waivers []Waiver `yaml:"waivers"`
+}
+
+// Waiver accepts the risk of findings until it expires.
+// Selectors are globs and empty selectors match everything.
+type Waiver struct {
+	RuleID     string            `yaml:"rule_id"`
+	Account    string            `yaml:"account,omitempty"`
+	Region     string            `yaml:"region,omitempty"`
+	Resource   string            `yaml:"resource,omitempty"`
+	ResourceID string            `yaml:"resource_id,omitempty"`
+	Tags       map[string]string `yaml:"tags,omitempty"`
+	Owner      string            `yaml:"owner"`
+	Reason     string            `yaml:"reason"`
+	Expires    string            `yaml:"expires"`
+
+	expires  time.Time
+	patterns map[string]*regexp.Regexp
+}
+
+// LoadWaivers reads waivers from the suppression file.
+// The default file is optional, but a file given explicitly must exist
+func LoadWaivers(file string) ([]Waiver, error) {
+	b, err := ioutil.ReadFile(file)
+	if err != nil {
+		if file == constants.DefaultIgnoreFile {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("cannot read suppression file %s: %v", file, err)
+	}
+
+	var wf WaiverFile
+	if err := yaml.UnmarshalStrict(b, &wf); err != nil {
+		return nil, fmt.Errorf("%s: %v", file, err)
+	}
+
+	for i := range wf.Waivers {
+		if err := wf.Waivers[i].compile(); err != nil {
+			return nil, fmt.Errorf("%s: %v", file, err)
+		}
+	}
+
+	return wf.Waivers, nil
+}
+
+// compile validates the waiver and prepares globs of selectors
+func (w *Waiver) compile() error {
+	if len(w.RuleID) == 0 {
+		return fmt.Errorf("waiver has no rule_id")
+	}
+
+	if len(w.Owner) == 0 || len(w.Reason) == 0 {
+		return fmt.Errorf("waiver of %s has no owner or reason", w.RuleID)
+	}
+
+	expires, err := time.Parse(waiverDateLayout, w.Expires)
+	if err != nil {
+		return fmt.Errorf("expiry date of waiver %s is not YYYY-MM-DD: %s", w.RuleID, w.Expires)
+	}
+	w.expires = expires
+
+	w.patterns = map[string]*regexp.Regexp{}
+	for _, g := range []string{w.RuleID, w.Account, w.Region, w.Resource, w.ResourceID} {
+		w.patterns[g] = globRegexp(g)
+	}
+	for _, g := range w.Tags {
+		w.patterns[g] = globRegexp(g)
+	}
+
+	return nil
+}
+
+// Expired checks if the waiver is expired. Waivers are valid until the end of the expiry date
+func (w Waiver) Expired(now time.Time) bool {
+	return !now.UTC().Before(w.expires.AddDate(0, 0, 1))
+}
+
+// Match checks if the finding is selected by the waiver
+func (w Waiver) Match(f Finding) bool {
+	selectors := [][2]string{
+		{w.RuleID, f.RuleID},
+		{w.Account, f.Account},
+		{w.Region, f.Region},
+		{w.Resource, f.ResourceType},
+		{w.ResourceID, f.ResourceID},
+	}
+
+	for _, s := range selectors {
+		if glob := s[0]; len(glob) > 0 && !w.patterns[glob].MatchString(s[1]) {
+			return false
+		}
+	}
+
+	for k, glob := range w.Tags {
+		value, ok := f.Tags[k]
+		if !ok || !w.patterns[glob].MatchString(value) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// ApplyWaivers hides findings accepted by valid waivers.
+// Findings of expired waivers come back and expired waivers are reported as findings
+func ApplyWaivers(findings []Finding, waivers []Waiver, now time.Time) Report {
+	var report Report
+	for _, f := range findings {
+		suppressed := false
+		for _, w := range waivers {
+			if !w.Expired(now) && w.Match(f) {
+				suppressed = true
+				break
+			}
+		}
+
+		if suppressed {
+			report.Suppressed = append(report.Suppressed, f)
+		} else {
+			report.Findings = append(report.Findings, f)
+		}
+	}
+
+	for _, w := range waivers {
+		if w.Expired(now) {
+			report.Findings = append(report.Findings, w.expiredFinding())
+		}
+	}
+
+	SortFindings(report.Findings)
+
+	return report
+}
+
+// expiredFinding returns a finding which reports the expired waiver
+func (w Waiver) expiredFinding() Finding {
+	selector := []string{w.RuleID}
+	if len(w.ResourceID) > 0 {
+		selector = append(selector, w.ResourceID)
+	}
+
+	return Finding{
+		RuleID:       WaiverExpiredRuleID,
+		Title:        waiverExpiredTitle,
+		Severity:     constants.SeverityLow,
+		ResourceType: waiverResourceType,
+		ResourceID:   strings.Join(selector, " "),
+		Region:       w.Region,
+		Account:      w.Account,
+		Message:      fmt.Sprintf("waiver of %s owned by %s expired on %s: %s", w.RuleID, w.Owner, w.Expires, w.Reason),
+	}
+}
+
+// globRegexp converts a glob with `*` and `?` to a regular expression
+func globRegexp(glob string) *regexp.Regexp {
+	var sb strings.Builder
+	sb.WriteString("^")
+	for _, r := range glob {
+		switch r {
+		case '*':
+			sb.WriteString(".*")
+		case '?':
+			sb.WriteString(".")
+		default:
+			sb.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	sb.WriteString("$")
+
+	return regexp.MustCompile(sb.String())
+}
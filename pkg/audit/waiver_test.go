@@ -0,0 +1,86 @@
+/*
+Copyright 2020 The redhawk Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DevopsArtFactory/redhawk/pkg/constants"
+)
+
+func TestApplyWaivers(t *testing.T) {
+	waivers := []Waiver{
+		{RuleID: "RH-S3-*", ResourceID: "logs-*", Owner: "data", Reason: "public logs", Expires: "2026-10-19"},
+		{RuleID: "RH-EC2-001", Tags: map[string]string{"Environment": "dev"}, Owner: "infra", Reason: "sandbox", Expires: "2026-12-31"},
+		{RuleID: "RH-IAM-001", Account: "prod", Owner: "security", Reason: "break glass", Expires: "2026-01-01"},
+	}
+	for i := range waivers {
+		if err := waivers[i].compile(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	findings := []Finding{
+		{RuleID: "RH-S3-002", Severity: constants.SeverityHigh, ResourceID: "logs-archive", Account: "prod"},
+		{RuleID: "RH-S3-002", Severity: constants.SeverityHigh, ResourceID: "backup", Account: "prod"},
+		{RuleID: "RH-EC2-001", Severity: constants.SeverityMedium, ResourceID: "i-1", Tags: map[string]string{"Environment": "dev"}},
+		{RuleID: "RH-EC2-001", Severity: constants.SeverityMedium, ResourceID: "i-2", Tags: map[string]string{"Environment": "prod"}},
+		{RuleID: "RH-IAM-001", Severity: constants.SeverityHigh, ResourceID: "root", Account: "prod"},
+	}
+
+	// The first waiver is valid until the end of the expiry date
+	report := ApplyWaivers(findings, waivers, time.Date(2026, 10, 19, 23, 0, 0, 0, time.UTC))
+
+	var suppressed []string
+	for _, f := range report.Suppressed {
+		suppressed = append(suppressed, f.ResourceID)
+	}
+	if len(suppressed) != 2 || suppressed[0] != "logs-archive" || suppressed[1] != "i-1" {
+		t.Errorf("unexpected suppressed findings: %v", suppressed)
+	}
+
+	var expired, returned int
+	for _, f := range report.Findings {
+		switch {
+		case f.RuleID == WaiverExpiredRuleID:
+			expired++
+		case f.ResourceID == "root":
+			returned++
+		}
+	}
+	if expired != 1 || returned != 1 || len(report.Findings) != 4 {
+		t.Errorf("expired waiver should be reported with its finding: %v", report.Findings)
+	}
+
+	report = ApplyWaivers(findings, waivers, time.Date(2026, 10, 20, 0, 0, 0, 0, time.UTC))
+	if len(report.Suppressed) != 1 {
+		t.Errorf("waiver should expire after the expiry date: %v", report.Suppressed)
+	}
+}
+
+func TestWaiverValidation(t *testing.T) {
+	for _, w := range []Waiver{
+		{Owner: "infra", Reason: "sandbox", Expires: "2026-12-31"},
+		{RuleID: "RH-EC2-001", Reason: "sandbox", Expires: "2026-12-31"},
+		{RuleID: "RH-EC2-001", Owner: "infra", Reason: "sandbox", Expires: "12/31/2026"},
+	} {
+		if err := w.compile(); err == nil {
+			t.Errorf("invalid waiver should fail: %+v", w)
+		}
+	}
+}
@@ -45,12 +45,16 @@ type Builder struct {
 }
 
 type Flags struct {
-	Detail    bool   `json:"detail"`
-	All       bool   `json:"all"`
-	Config    string `json:"config"`
-	Resources string `json:"resources"`
-	Output    string `json:"output"`
-	Region    string `json:"region"`
+	Detail     bool   `json:"detail"`
+	All        bool   `json:"all"`
+	Config     string `json:"config"`
+	Resources  string `json:"resources"`
+	Output     string `json:"output"`
+	Region     string `json:"region"`
+	Profile    string `json:"profile"`
+	IgnoreFile string `json:"ignore_file"`
+	FailOn     string `json:"fail_on"`
+	Framework  string `json:"framework"`
 }
 
 // ValidateFlags checks validation of flags
@@ -59,6 +63,25 @@ func ValidateFlags(flags Flags) error {
 		return printHelp(flags.Region)
 	}
 
+	return validateCommonFlags(flags)
+}
+
+// ValidateAuditFlags checks validation of flags for audit.
+// Default resources are audited if no resource is specified
+func ValidateAuditFlags(flags Flags) error {
+	if len(flags.FailOn) > 0 && !tools.IsStringInArray(flags.FailOn, constants.Severities) {
+		return fmt.Errorf("severity of --fail-on is not supported: %s", flags.FailOn)
+	}
+
+	if len(flags.Framework) > 0 && !tools.IsStringInArray(flags.Framework, constants.Frameworks) {
+		return fmt.Errorf("framework is not supported: %s", flags.Framework)
+	}
+
+	return validateCommonFlags(flags)
+}
+
+// validateCommonFlags checks flags shared by commands
+func validateCommonFlags(flags Flags) error {
 	if !tools.IsStringInArray(flags.Output, constants.ValidFormats) {
 		return fmt.Errorf("output format is not supported: %s", flags.Output)
 	}
@@ -0,0 +1,196 @@
+/*
+Copyright 2020 The redhawk Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"errors"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/account"
+	accountTypes "github.com/aws/aws-sdk-go-v2/service/account/types"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	"github.com/aws/aws-sdk-go-v2/service/iam/types"
+	"github.com/sirupsen/logrus"
+
+	"github.com/DevopsArtFactory/redhawk/pkg/constants"
+	"github.com/DevopsArtFactory/redhawk/pkg/resource"
+)
+
+type AccountClient struct {
+	Resource   string
+	Client     *account.Client
+	IAM        *iam.Client
+	STS        *STSClient
+	CloudTrail *CloudTrailClient
+	Alias      *string
+}
+
+// GetResourceName returns resource name of client
+func (a *AccountClient) GetResourceName() string {
+	return a.Resource
+}
+
+// NewAccountClient creates a AccountClient
+func NewAccountClient(cfg aws.Config, _ Helper) (Client, error) {
+	sts, err := NewSTSClient(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return &AccountClient{
+		Resource: constants.AccountResourceName,
+		Client:   GetAccountClientFn(cfg),
+		IAM:      GetIAMClientFn(cfg),
+		STS:      sts,
+		CloudTrail: &CloudTrailClient{
+			Client: GetCloudTrailClientFn(cfg),
+		},
+	}, nil
+}
+
+// GetAccountClientFn creates account client
+func GetAccountClientFn(cfg aws.Config) *account.Client {
+	return account.NewFromConfig(cfg)
+}
+
+// Scan scans all data
+func (a *AccountClient) Scan() ([]resource.Resource, error) {
+	logrus.Debug("Start scanning account settings")
+
+	tmp := resource.AccountResource{
+		ResourceType: aws.String(constants.AccountResourceName),
+		AccountAlias: a.Alias,
+	}
+
+	accountID, err := a.STS.GetAccountID()
+	if err != nil {
+		return nil, err
+	}
+	tmp.AccountID = accountID
+
+	summary, err := a.GetAccountSummary()
+	if err != nil {
+		return nil, err
+	}
+	tmp.RootMFAEnabled = aws.Bool(summary[string(types.SummaryKeyTypeAccountMFAEnabled)] > 0)
+	tmp.RootAccessKeysPresent = aws.Bool(summary[string(types.SummaryKeyTypeAccountAccessKeysPresent)] > 0)
+
+	policy, err := a.GetPasswordPolicy()
+	if err != nil {
+		return nil, err
+	}
+
+	setPasswordPolicy(&tmp, policy)
+
+	contacts := map[accountTypes.AlternateContactType]**string{
+		accountTypes.AlternateContactTypeBilling:    &tmp.BillingContact,
+		accountTypes.AlternateContactTypeOperations: &tmp.OperationsContact,
+		accountTypes.AlternateContactTypeSecurity:   &tmp.SecurityContact,
+	}
+
+	for contactType, field := range contacts {
+		contact, err := a.GetAlternateContact(contactType)
+		if err != nil {
+			logrus.Debugf("cannot get %s alternate contact: %s", contactType, err.Error())
+			continue
+		}
+
+		if contact == nil {
+			*field = aws.String(constants.EmptyString)
+		} else {
+			*field = contact.EmailAddress
+		}
+	}
+
+	multiRegionTrail, err := a.CloudTrail.HasMultiRegionTrail()
+	if err != nil {
+		logrus.Debugf("cannot check multi-region trails: %s", err.Error())
+	} else {
+		tmp.MultiRegionTrail = aws.Bool(multiRegionTrail)
+	}
+
+	return []resource.Resource{tmp}, nil
+}
+
+// setPasswordPolicy maps IAM password policy to fields of the account resource
+func setPasswordPolicy(tmp *resource.AccountResource, policy *types.PasswordPolicy) {
+	tmp.PasswordPolicy = aws.Bool(policy != nil)
+	if policy != nil {
+		tmp.RequireSymbols = aws.Bool(policy.RequireSymbols)
+		tmp.RequireNumbers = aws.Bool(policy.RequireNumbers)
+		tmp.RequireUppercase = aws.Bool(policy.RequireUppercaseCharacters)
+		tmp.RequireLowercase = aws.Bool(policy.RequireLowercaseCharacters)
+
+		if policy.MinimumPasswordLength != nil {
+			tmp.MinimumPasswordLength = aws.Int(int(*policy.MinimumPasswordLength))
+		}
+
+		if policy.PasswordReusePrevention != nil {
+			tmp.PasswordReusePrevention = aws.Int(int(*policy.PasswordReusePrevention))
+		}
+
+		if policy.ExpirePasswords && policy.MaxPasswordAge != nil {
+			tmp.MaxPasswordAge = aws.Int(int(*policy.MaxPasswordAge))
+		}
+	}
+}
+
+// GetAccountSummary returns IAM entity usage and quotas of the account
+func (a *AccountClient) GetAccountSummary() (map[string]int32, error) {
+	result, err := a.IAM.GetAccountSummary(context.TODO(), &iam.GetAccountSummaryInput{})
+	if err != nil {
+		return nil, err
+	}
+
+	return result.SummaryMap, nil
+}
+
+// GetPasswordPolicy returns password policy of the account. nil is returned if no policy is set
+func (a *AccountClient) GetPasswordPolicy() (*types.PasswordPolicy, error) {
+	result, err := a.IAM.GetAccountPasswordPolicy(context.TODO(), &iam.GetAccountPasswordPolicyInput{})
+	if err != nil {
+		var notFound *types.NoSuchEntityException
+		if errors.As(err, &notFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return result.PasswordPolicy, nil
+}
+
+// GetAlternateContact returns alternate contact of the account. nil is returned if contact is not set
+func (a *AccountClient) GetAlternateContact(contactType accountTypes.AlternateContactType) (*accountTypes.AlternateContact, error) {
+	result, err := a.Client.GetAlternateContact(context.TODO(), &account.GetAlternateContactInput{
+		AlternateContactType: contactType,
+	})
+	if err != nil {
+		var notFound *accountTypes.ResourceNotFoundException
+		if errors.As(err, &notFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return result.AlternateContact, nil
+}
+
+// SetAlias sets alias
+func (a *AccountClient) SetAlias(alias *string) {
+	a.Alias = alias
+}
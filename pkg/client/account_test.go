@@ -0,0 +1,89 @@
+/*
+Copyright 2020 The redhawk Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/iam/types"
+
+	"github.com/DevopsArtFactory/redhawk/pkg/resource"
+)
+
+func TestSetPasswordPolicy(t *testing.T) {
+	tests := []struct {
+		description string
+		policy      *types.PasswordPolicy
+		expected    resource.AccountResource
+	}{
+		{
+			description: "no password policy",
+			expected: resource.AccountResource{
+				PasswordPolicy: aws.Bool(false),
+			},
+		},
+		{
+			description: "password policy without expiration",
+			policy: &types.PasswordPolicy{
+				RequireSymbols:             true,
+				RequireNumbers:             true,
+				RequireUppercaseCharacters: false,
+				RequireLowercaseCharacters: true,
+				MinimumPasswordLength:      aws.Int32(8),
+				PasswordReusePrevention:    aws.Int32(5),
+				ExpirePasswords:            false,
+				MaxPasswordAge:             aws.Int32(90),
+			},
+			expected: resource.AccountResource{
+				PasswordPolicy:          aws.Bool(true),
+				RequireSymbols:          aws.Bool(true),
+				RequireNumbers:          aws.Bool(true),
+				RequireUppercase:        aws.Bool(false),
+				RequireLowercase:        aws.Bool(true),
+				MinimumPasswordLength:   aws.Int(8),
+				PasswordReusePrevention: aws.Int(5),
+			},
+		},
+		{
+			description: "password policy with expiration",
+			policy: &types.PasswordPolicy{
+				MinimumPasswordLength: aws.Int32(14),
+				ExpirePasswords:       true,
+				MaxPasswordAge:        aws.Int32(90),
+			},
+			expected: resource.AccountResource{
+				PasswordPolicy:        aws.Bool(true),
+				RequireSymbols:        aws.Bool(false),
+				RequireNumbers:        aws.Bool(false),
+				RequireUppercase:      aws.Bool(false),
+				RequireLowercase:      aws.Bool(false),
+				MinimumPasswordLength: aws.Int(14),
+				MaxPasswordAge:        aws.Int(90),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		var got resource.AccountResource
+		setPasswordPolicy(&got, test.policy)
+		if !reflect.DeepEqual(got, test.expected) {
+			t.Errorf("%s: expected %+v, got %+v", test.description, test.expected, got)
+		}
+	}
+}
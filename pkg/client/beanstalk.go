@@ -0,0 +1,106 @@
+/*
+Copyright 2020 The redhawk Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/elasticbeanstalk"
+	"github.com/aws/aws-sdk-go-v2/service/elasticbeanstalk/types"
+	"github.com/sirupsen/logrus"
+
+	"github.com/DevopsArtFactory/redhawk/pkg/constants"
+	"github.com/DevopsArtFactory/redhawk/pkg/resource"
+)
+
+type BeanstalkClient struct {
+	Resource string
+	Client   *elasticbeanstalk.Client
+	Region   string
+	Alias    *string
+}
+
+// GetResourceName returns resource name of client
+func (b *BeanstalkClient) GetResourceName() string {
+	return b.Resource
+}
+
+// NewBeanstalkClient creates a BeanstalkClient
+func NewBeanstalkClient(cfg aws.Config, helper Helper) (Client, error) {
+	return &BeanstalkClient{
+		Resource: constants.BeanstalkResourceName,
+		Client:   GetBeanstalkClientFn(cfg),
+		Region:   helper.Region,
+	}, nil
+}
+
+// GetBeanstalkClientFn creates elasticbeanstalk client
+func GetBeanstalkClientFn(cfg aws.Config) *elasticbeanstalk.Client {
+	return elasticbeanstalk.NewFromConfig(cfg)
+}
+
+// Scan scans all data
+func (b *BeanstalkClient) Scan() ([]resource.Resource, error) {
+	var result []resource.Resource
+
+	logrus.Debug("Start scanning all elastic beanstalk environments in the region")
+	environments, err := b.GetEnvironments(nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, env := range environments {
+		result = append(result, resource.BeanstalkResource{
+			ResourceType:    aws.String(constants.BeanstalkResourceName),
+			ApplicationName: env.ApplicationName,
+			EnvironmentName: env.EnvironmentName,
+			EnvironmentID:   env.EnvironmentId,
+			Region:          aws.String(b.Region),
+			CNAME:           env.CNAME,
+			EndpointURL:     env.EndpointURL,
+			Status:          aws.String(string(env.Status)),
+			Health:          aws.String(string(env.Health)),
+			Created:         env.DateCreated,
+		})
+	}
+	logrus.Debugf("total elastic beanstalk environment count: %d", len(result))
+
+	return result, nil
+}
+
+// GetEnvironments returns all environments in the region except terminated ones
+func (b *BeanstalkClient) GetEnvironments(original []types.EnvironmentDescription, nextToken *string) ([]types.EnvironmentDescription, error) {
+	result, err := b.Client.DescribeEnvironments(context.TODO(), &elasticbeanstalk.DescribeEnvironmentsInput{
+		IncludeDeleted: aws.Bool(false),
+		NextToken:      nextToken,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	original = append(original, result.Environments...)
+	if result.NextToken != nil {
+		return b.GetEnvironments(original, result.NextToken)
+	}
+	return original, nil
+}
+
+// SetAlias sets alias
+func (b *BeanstalkClient) SetAlias(alias *string) {
+	b.Alias = alias
+}
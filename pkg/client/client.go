@@ -28,16 +28,23 @@ type Client interface {
 	Scan() ([]resource.Resource, error)
 }
 
+// RegionScopedClient is a client which lists resources of all regions from any region.
+// Resources of other regions are skipped after ScopeToRegion is called
+type RegionScopedClient interface {
+	ScopeToRegion()
+}
+
 type Helper struct {
 	Provider string
 	Resource string
 	Region   string
+	RoleArn  string
 }
 
 // ChooseResourceClient selects resource client from the list
 func ChooseResourceClient(resource string, h Helper) (Client, error) {
 	var err error
-	cfg := GetAwsSession(h.Region)
+	cfg := GetAwsSessionWithRole(h.Region, h.RoleArn)
 
 	// Get Account alias
 	alias, err := GetAccountAlias(cfg)
@@ -61,10 +68,11 @@ func ChooseResourceClient(resource string, h Helper) (Client, error) {
 }
 
 // CreateResourceClient creates a new client fro redhawk
-func CreateResourceClient(provider, resource, region string) (Client, error) {
+func CreateResourceClient(provider, resource, region, roleArn string) (Client, error) {
 	h := Helper{
 		Provider: provider,
 		Region:   region,
+		RoleArn:  roleArn,
 	}
 
 	return ChooseResourceClient(resource, h)
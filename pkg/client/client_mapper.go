@@ -26,9 +26,30 @@ var (
 	clientMapper = map[string]func(aws.Config, Helper) (Client, error){
 		constants.EC2ResourceName:     NewEC2Client,
 		constants.SGResourceName:      NewSGClient,
+		constants.SGRuleResourceName:  NewSGRuleClient,
 		constants.Route53ResourceName: NewRoute53Client,
 		constants.S3ResourceName:      NewS3Client,
 		constants.RDSResourceName:     NewRDSClient,
 		constants.IAMResourceName:     NewIAMClient,
+		constants.EFSResourceName:     NewEFSClient,
+		constants.FSxResourceName:     NewFSxClient,
+		constants.KinesisResourceName: NewKinesisClient,
+		constants.MSKResourceName:     NewMSKClient,
+		constants.AccountResourceName: NewAccountClient,
+
+		constants.EIPResourceName:          NewEIPClient,
+		constants.KeyPairResourceName:      NewKeyPairClient,
+		constants.SecretResourceName:       NewSecretClient,
+		constants.LoadBalancerResourceName: NewLoadBalancerClient,
+		constants.CloudTrailResourceName:   NewCloudTrailClient,
+		constants.VPCResourceName:          NewVPCClient,
+		constants.CloudFrontResourceName:   NewCloudFrontClient,
+		constants.BeanstalkResourceName:    NewBeanstalkClient,
+
+		constants.OrganizationResourceName: NewOrganizationsClient,
+		constants.SSOResourceName:          NewSSOClient,
+
+		constants.IAMEscalationResourceName: NewIAMEscalationClient,
+		constants.ExposureResourceName:      NewExposureClient,
 	}
 )
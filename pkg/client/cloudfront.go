@@ -0,0 +1,118 @@
+/*
+Copyright 2020 The redhawk Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudfront"
+	"github.com/aws/aws-sdk-go-v2/service/cloudfront/types"
+	"github.com/sirupsen/logrus"
+
+	"github.com/DevopsArtFactory/redhawk/pkg/constants"
+	"github.com/DevopsArtFactory/redhawk/pkg/resource"
+)
+
+type CloudFrontClient struct {
+	Resource string
+	Client   *cloudfront.Client
+	Alias    *string
+}
+
+// GetResourceName returns resource name of client
+func (c *CloudFrontClient) GetResourceName() string {
+	return c.Resource
+}
+
+// NewCloudFrontClient creates a CloudFrontClient
+func NewCloudFrontClient(cfg aws.Config, _ Helper) (Client, error) {
+	return &CloudFrontClient{
+		Resource: constants.CloudFrontResourceName,
+		Client:   GetCloudFrontClientFn(cfg),
+	}, nil
+}
+
+// GetCloudFrontClientFn creates cloudfront client
+func GetCloudFrontClientFn(cfg aws.Config) *cloudfront.Client {
+	return cloudfront.NewFromConfig(cfg)
+}
+
+// Scan scans all data
+func (c *CloudFrontClient) Scan() ([]resource.Resource, error) {
+	var result []resource.Resource
+
+	logrus.Debug("Start scanning all cloudfront distributions in the account")
+	distributions, err := c.GetDistributions(nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, d := range distributions {
+		tmp := resource.CloudFrontResource{
+			ResourceType:   aws.String(constants.CloudFrontResourceName),
+			DistributionID: d.Id,
+			DomainName:     d.DomainName,
+			Status:         d.Status,
+			Enabled:        d.Enabled,
+			LastModified:   d.LastModifiedTime,
+		}
+
+		if d.Aliases != nil && len(d.Aliases.Items) > 0 {
+			tmp.Aliases = aws.String(strings.Join(d.Aliases.Items, constants.DefaultDelimiter))
+		}
+
+		if d.Origins != nil {
+			var origins []string
+			for _, origin := range d.Origins.Items {
+				origins = append(origins, aws.ToString(origin.DomainName))
+			}
+			tmp.Origins = aws.String(strings.Join(origins, constants.DefaultDelimiter))
+		}
+
+		result = append(result, tmp)
+	}
+	logrus.Debugf("total cloudfront distribution count: %d", len(result))
+
+	return result, nil
+}
+
+// GetDistributions returns all distributions in the account
+func (c *CloudFrontClient) GetDistributions(original []types.DistributionSummary, marker *string) ([]types.DistributionSummary, error) {
+	result, err := c.Client.ListDistributions(context.TODO(), &cloudfront.ListDistributionsInput{
+		Marker: marker,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if result.DistributionList == nil {
+		return original, nil
+	}
+
+	original = append(original, result.DistributionList.Items...)
+	if result.DistributionList.NextMarker != nil {
+		return c.GetDistributions(original, result.DistributionList.NextMarker)
+	}
+	return original, nil
+}
+
+// SetAlias sets alias
+func (c *CloudFrontClient) SetAlias(alias *string) {
+	c.Alias = alias
+}
@@ -0,0 +1,172 @@
+/*
+Copyright 2020 The redhawk Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudtrail"
+	"github.com/aws/aws-sdk-go-v2/service/cloudtrail/types"
+	"github.com/sirupsen/logrus"
+
+	"github.com/DevopsArtFactory/redhawk/pkg/constants"
+	"github.com/DevopsArtFactory/redhawk/pkg/resource"
+)
+
+type CloudTrailClient struct {
+	Resource string
+	Client   *cloudtrail.Client
+	Region   string
+	Alias    *string
+}
+
+// GetResourceName returns resource name of client
+func (c *CloudTrailClient) GetResourceName() string {
+	return c.Resource
+}
+
+// NewCloudTrailClient creates a CloudTrailClient
+func NewCloudTrailClient(cfg aws.Config, helper Helper) (Client, error) {
+	return &CloudTrailClient{
+		Resource: constants.CloudTrailResourceName,
+		Client:   GetCloudTrailClientFn(cfg),
+		Region:   helper.Region,
+	}, nil
+}
+
+// GetCloudTrailClientFn creates cloudtrail client
+func GetCloudTrailClientFn(cfg aws.Config) *cloudtrail.Client {
+	return cloudtrail.NewFromConfig(cfg)
+}
+
+// Scan scans trails created in the region.
+// Multi-region trails are reported only in their home region
+func (c *CloudTrailClient) Scan() ([]resource.Resource, error) {
+	var result []resource.Resource
+
+	logrus.Debug("Start scanning all trails in the region")
+	trails, err := c.GetTrails(false)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, trail := range trails {
+		status, err := c.GetTrailStatus(trail.TrailARN)
+		if err != nil {
+			return nil, err
+		}
+
+		tmp := resource.CloudTrailResource{
+			ResourceType:                 aws.String(constants.CloudTrailResourceName),
+			Name:                         trail.Name,
+			TrailARN:                     trail.TrailARN,
+			Region:                       trail.HomeRegion,
+			MultiRegion:                  aws.Bool(aws.ToBool(trail.IsMultiRegionTrail)),
+			OrganizationTrail:            aws.Bool(aws.ToBool(trail.IsOrganizationTrail)),
+			Logging:                      aws.Bool(aws.ToBool(status.IsLogging)),
+			LogFileValidation:            aws.Bool(aws.ToBool(trail.LogFileValidationEnabled)),
+			S3Bucket:                     trail.S3BucketName,
+			CloudWatchLogsLogGroup:       trail.CloudWatchLogsLogGroupArn,
+			LatestCloudWatchLogsDelivery: status.LatestCloudWatchLogsDeliveryTime,
+			KmsKeyID:                     trail.KmsKeyId,
+		}
+
+		result = append(result, tmp)
+	}
+	logrus.Debugf("total trail count: %d", len(result))
+
+	return result, nil
+}
+
+// GetTrails returns trails of the region.
+// Shadow trails are multi-region trails created in other regions
+func (c *CloudTrailClient) GetTrails(includeShadowTrails bool) ([]types.Trail, error) {
+	result, err := c.Client.DescribeTrails(context.TODO(), &cloudtrail.DescribeTrailsInput{
+		IncludeShadowTrails: aws.Bool(includeShadowTrails),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result.TrailList, nil
+}
+
+// GetTrailStatus returns logging status of trail. ARN is needed for shadow trails
+func (c *CloudTrailClient) GetTrailStatus(trailARN *string) (*cloudtrail.GetTrailStatusOutput, error) {
+	return c.Client.GetTrailStatus(context.TODO(), &cloudtrail.GetTrailStatusInput{
+		Name: trailARN,
+	})
+}
+
+// HasMultiRegionTrail checks if a multi-region trail with log file validation is logging
+func (c *CloudTrailClient) HasMultiRegionTrail() (bool, error) {
+	trails, err := c.GetTrails(true)
+	if err != nil {
+		return false, err
+	}
+
+	return hasMultiRegionTrail(trails, func(trailARN *string) (bool, error) {
+		status, err := c.GetTrailStatus(trailARN)
+		if err != nil {
+			return false, err
+		}
+		return aws.ToBool(status.IsLogging), nil
+	})
+}
+
+// hasMultiRegionTrail checks logging status of multi-region trails with log file validation.
+// Status of other trails is not requested
+func hasMultiRegionTrail(trails []types.Trail, isLogging func(trailARN *string) (bool, error)) (bool, error) {
+	for _, trail := range trails {
+		if !aws.ToBool(trail.IsMultiRegionTrail) || !aws.ToBool(trail.LogFileValidationEnabled) {
+			continue
+		}
+
+		logging, err := isLogging(trail.TrailARN)
+		if err != nil {
+			return false, err
+		}
+
+		if logging {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// GetTrailBuckets returns buckets which trails of the region write logs to
+func (c *CloudTrailClient) GetTrailBuckets() (map[string]bool, error) {
+	trails, err := c.GetTrails(true)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := map[string]bool{}
+	for _, trail := range trails {
+		if trail.S3BucketName != nil {
+			ret[*trail.S3BucketName] = true
+		}
+	}
+	return ret, nil
+}
+
+// SetAlias sets alias
+func (c *CloudTrailClient) SetAlias(alias *string) {
+	c.Alias = alias
+}
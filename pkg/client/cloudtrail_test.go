@@ -0,0 +1,87 @@
+/*
+Copyright 2020 The redhawk Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudtrail/types"
+)
+
+func TestHasMultiRegionTrail(t *testing.T) {
+	regional := types.Trail{TrailARN: aws.String("regional"), LogFileValidationEnabled: aws.Bool(true)}
+	noValidation := types.Trail{TrailARN: aws.String("no-validation"), IsMultiRegionTrail: aws.Bool(true)}
+	stopped := types.Trail{TrailARN: aws.String("stopped"), IsMultiRegionTrail: aws.Bool(true), LogFileValidationEnabled: aws.Bool(true)}
+	logging := types.Trail{TrailARN: aws.String("logging"), IsMultiRegionTrail: aws.Bool(true), LogFileValidationEnabled: aws.Bool(true)}
+
+	status := map[string]bool{"regional": true, "no-validation": true, "stopped": false, "logging": true}
+
+	tests := []struct {
+		description string
+		trails      []types.Trail
+		expected    bool
+		checked     []string
+	}{
+		{
+			description: "no trail",
+			expected:    false,
+		},
+		{
+			description: "only trails which are not candidates are logging",
+			trails:      []types.Trail{regional, noValidation},
+			expected:    false,
+		},
+		{
+			description: "multi-region trail is stopped",
+			trails:      []types.Trail{regional, stopped},
+			expected:    false,
+			checked:     []string{"stopped"},
+		},
+		{
+			description: "multi-region trail is logging",
+			trails:      []types.Trail{noValidation, stopped, logging},
+			expected:    true,
+			checked:     []string{"stopped", "logging"},
+		},
+	}
+
+	for _, test := range tests {
+		var checked []string
+		got, err := hasMultiRegionTrail(test.trails, func(trailARN *string) (bool, error) {
+			checked = append(checked, *trailARN)
+			return status[*trailARN], nil
+		})
+		if err != nil {
+			t.Fatalf("%s: %v", test.description, err)
+		}
+		if got != test.expected {
+			t.Errorf("%s: expected %t, got %t", test.description, test.expected, got)
+		}
+		if !reflect.DeepEqual(checked, test.checked) {
+			t.Errorf("%s: expected status of %v to be checked, got %v", test.description, test.checked, checked)
+		}
+	}
+
+	if _, err := hasMultiRegionTrail([]types.Trail{logging}, func(*string) (bool, error) {
+		return false, errors.New("access denied")
+	}); err == nil {
+		t.Error("expected error from trail status to be returned")
+	}
+}
@@ -18,8 +18,11 @@ package client
 
 import (
 	"context"
+	"fmt"
+	"regexp"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
@@ -30,6 +33,9 @@ import (
 	"github.com/DevopsArtFactory/redhawk/pkg/resource"
 )
 
+// stateTransitionRegex matches time in the state transition reason of instance
+var stateTransitionRegex = regexp.MustCompile(`\((\d{4}-\d{2}-\d{2} \d{2}:\d{2}:\d{2}) GMT\)`)
+
 type EC2Client struct {
 	Resource string
 	Client   *ec2.Client
@@ -63,6 +69,17 @@ func (e *EC2Client) Scan() ([]resource.Resource, error) {
 		return nil, nil
 	}
 
+	// Unencrypted volumes are unknown if volumes cannot be listed
+	volumes, err := e.GetVolumes(nil, nil)
+	if err != nil {
+		logrus.Warnf("cannot get volumes: %s", err.Error())
+	}
+
+	encrypted := map[string]bool{}
+	for _, volume := range volumes {
+		encrypted[aws.ToString(volume.VolumeId)] = aws.ToBool(volume.Encrypted)
+	}
+
 	input := make(chan resource.EC2Resource)
 	output := make(chan []resource.Resource)
 	defer close(output)
@@ -87,6 +104,9 @@ func (e *EC2Client) Scan() ([]resource.Resource, error) {
 		tmp.InstanceType = aws.String(string(instance.InstanceType))
 		tmp.AvailabilityZone = instance.Placement.AvailabilityZone
 		tmp.LaunchTime = instance.LaunchTime
+		if instance.State.Name == types.InstanceStateNameStopped {
+			tmp.StoppedAt = ParseStateTransitionTime(aws.ToString(instance.StateTransitionReason))
+		}
 		tmp.ImageID = instance.ImageId
 		tmp.VpcID = instance.VpcId
 		tmp.SubnetID = instance.SubnetId
@@ -101,15 +121,22 @@ func (e *EC2Client) Scan() ([]resource.Resource, error) {
 			tmp.KeyName = instance.KeyName
 		}
 
-		//if instance.IamInstanceProfile != nil {
-		//	tmp.IAMInstanceProfile = instance.IamInstanceProfile.Arn
-		//}
+		if instance.IamInstanceProfile != nil {
+			tmp.IAMInstanceProfile = instance.IamInstanceProfile.Arn
+		}
 
-		for _, tag := range instance.Tags {
-			if *tag.Key == "Name" {
-				tmp.Name = tag.Value
-				break
-			}
+		if instance.MetadataOptions != nil {
+			tmp.MetadataHTTPTokens = aws.String(string(instance.MetadataOptions.HttpTokens))
+			tmp.MetadataHTTPEndpoint = aws.String(string(instance.MetadataOptions.HttpEndpoint))
+		}
+
+		if unencrypted := unencryptedVolumes(instance, encrypted); len(unencrypted) > 0 {
+			tmp.UnencryptedVolumes = aws.String(strings.Join(unencrypted, constants.DefaultDelimiter))
+		}
+
+		tmp.Tags = ec2Tags(instance.Tags)
+		if name, ok := tmp.Tags["Name"]; ok {
+			tmp.Name = aws.String(name)
 		}
 
 		var privateIps []string
@@ -157,11 +184,52 @@ func (e *EC2Client) Scan() ([]resource.Resource, error) {
 	return result, nil
 }
 
+// unencryptedVolumes returns EBS volumes of instance which are not encrypted with their device names.
+// Volumes which are not in the encryption map are skipped
+func unencryptedVolumes(instance types.Instance, encrypted map[string]bool) []string {
+	var ret []string
+	for _, bdm := range instance.BlockDeviceMappings {
+		if bdm.Ebs == nil {
+			continue
+		}
+
+		volumeID := aws.ToString(bdm.Ebs.VolumeId)
+		if enc, ok := encrypted[volumeID]; ok && !enc {
+			ret = append(ret, fmt.Sprintf("%s(%s)", volumeID, aws.ToString(bdm.DeviceName)))
+		}
+	}
+	return ret
+}
+
 // SetAlias sets alias
 func (e *EC2Client) SetAlias(alias *string) {
 	e.Alias = alias
 }
 
+// ParseStateTransitionTime returns time in the state transition reason of instance,
+// for example `User initiated (2019-11-13 08:22:35 GMT)`
+func ParseStateTransitionTime(reason string) *time.Time {
+	m := stateTransitionRegex.FindStringSubmatch(reason)
+	if m == nil {
+		return nil
+	}
+
+	t, err := time.Parse(constants.StateTransitionTimeFormat, m[1])
+	if err != nil {
+		return nil
+	}
+	return &t
+}
+
+// ec2Tags converts tags of EC2 resources
+func ec2Tags(tags []types.Tag) resource.Tags {
+	ret := resource.Tags{}
+	for _, tag := range tags {
+		ret[aws.ToString(tag.Key)] = aws.ToString(tag.Value)
+	}
+	return ret
+}
+
 // GetEC2Instances get all instances in the account
 func (e *EC2Client) GetEC2Instances(original []types.Reservation, nextToken *string) ([]types.Reservation, error) {
 	result, err := e.Client.DescribeInstances(context.TODO(), &ec2.DescribeInstancesInput{
@@ -178,6 +246,22 @@ func (e *EC2Client) GetEC2Instances(original []types.Reservation, nextToken *str
 	return original, nil
 }
 
+// GetVolumes returns all EBS volumes in the region
+func (e *EC2Client) GetVolumes(original []types.Volume, nextToken *string) ([]types.Volume, error) {
+	result, err := e.Client.DescribeVolumes(context.TODO(), &ec2.DescribeVolumesInput{
+		NextToken: nextToken,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	original = append(original, result.Volumes...)
+	if result.NextToken != nil {
+		return e.GetVolumes(original, result.NextToken)
+	}
+	return original, nil
+}
+
 // NewEC2Client creates EC2Client resource with ec2 client
 func NewEC2Client(cfg aws.Config, helper Helper) (Client, error) {
 	return &EC2Client{
@@ -0,0 +1,63 @@
+/*
+Copyright 2020 The redhawk Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+)
+
+func TestUnencryptedVolumes(t *testing.T) {
+	instance := types.Instance{
+		BlockDeviceMappings: []types.InstanceBlockDeviceMapping{
+			{DeviceName: aws.String("/dev/xvda"), Ebs: &types.EbsInstanceBlockDevice{VolumeId: aws.String("vol-1")}},
+			{DeviceName: aws.String("/dev/xvdb"), Ebs: &types.EbsInstanceBlockDevice{VolumeId: aws.String("vol-2")}},
+			{DeviceName: aws.String("/dev/xvdc"), Ebs: &types.EbsInstanceBlockDevice{VolumeId: aws.String("vol-3")}},
+			{DeviceName: aws.String("/dev/sdb")},
+		},
+	}
+
+	// vol-3 is not returned by DescribeVolumes
+	encrypted := map[string]bool{"vol-1": true, "vol-2": false}
+
+	if got := strings.Join(unencryptedVolumes(instance, encrypted), ","); got != "vol-2(/dev/xvdb)" {
+		t.Errorf("unexpected unencrypted volumes: %s", got)
+	}
+}
+
+func TestKeyPairUsage(t *testing.T) {
+	instance := func(key string, state types.InstanceStateName) types.Instance {
+		i := types.Instance{State: &types.InstanceState{Name: state}}
+		if len(key) > 0 {
+			i.KeyName = aws.String(key)
+		}
+		return i
+	}
+
+	reservations := []types.Reservation{
+		{Instances: []types.Instance{instance("deploy", types.InstanceStateNameRunning), instance("deploy", types.InstanceStateNameStopped)}},
+		{Instances: []types.Instance{instance("old", types.InstanceStateNameTerminated), instance("", types.InstanceStateNameRunning)}},
+	}
+
+	usage := keyPairUsage(reservations)
+	if usage["deploy"] != 2 || usage["old"] != 0 || len(usage) != 1 {
+		t.Errorf("unexpected key pair usage: %v", usage)
+	}
+}
@@ -0,0 +1,232 @@
+/*
+Copyright 2020 The redhawk Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/efs"
+	"github.com/aws/aws-sdk-go-v2/service/efs/types"
+	"github.com/sirupsen/logrus"
+
+	"github.com/DevopsArtFactory/redhawk/pkg/constants"
+	"github.com/DevopsArtFactory/redhawk/pkg/resource"
+)
+
+type EFSClient struct {
+	Resource string
+	Client   *efs.Client
+	Region   string
+	Alias    *string
+}
+
+// GetResourceName returns resource name of client
+func (e *EFSClient) GetResourceName() string {
+	return e.Resource
+}
+
+// NewEFSClient creates a EFSClient
+func NewEFSClient(cfg aws.Config, helper Helper) (Client, error) {
+	return &EFSClient{
+		Resource: constants.EFSResourceName,
+		Client:   GetEFSClientFn(cfg),
+		Region:   helper.Region,
+	}, nil
+}
+
+// GetEFSClientFn creates efs client
+func GetEFSClientFn(cfg aws.Config) *efs.Client {
+	return efs.NewFromConfig(cfg)
+}
+
+// Scan scans all data
+func (e *EFSClient) Scan() ([]resource.Resource, error) {
+	var wg sync.WaitGroup
+	var result []resource.Resource
+
+	logrus.Debug("Start scanning all EFS file systems in the region")
+	fileSystems, err := e.GetFileSystemList(nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(fileSystems) == 0 {
+		logrus.Debug("no EFS file system found")
+		return nil, nil
+	}
+
+	input := make(chan *resource.EFSResource)
+	output := make(chan []resource.Resource)
+	defer close(output)
+
+	go func(input chan *resource.EFSResource, output chan []resource.Resource, wg *sync.WaitGroup) {
+		var ret []resource.Resource
+		for result := range input {
+			if result != nil {
+				ret = append(ret, *result)
+			}
+			wg.Done()
+		}
+
+		output <- ret
+	}(input, output, &wg)
+
+	f := func(fs types.FileSystemDescription, ch chan *resource.EFSResource) {
+		tmp := resource.EFSResource{
+			ResourceType: aws.String(constants.EFSResourceName),
+		}
+
+		tmp.Name = fs.Name
+		tmp.FileSystemID = fs.FileSystemId
+		tmp.Region = aws.String(e.Region)
+		tmp.LifeCycleState = aws.String(string(fs.LifeCycleState))
+		tmp.PerformanceMode = aws.String(string(fs.PerformanceMode))
+		tmp.ThroughputMode = aws.String(string(fs.ThroughputMode))
+		tmp.Encrypted = fs.Encrypted
+		tmp.KmsKeyID = fs.KmsKeyId
+		tmp.Created = fs.CreationTime
+
+		tmp.Tags = resource.Tags{}
+		for _, tag := range fs.Tags {
+			tmp.Tags[aws.ToString(tag.Key)] = aws.ToString(tag.Value)
+		}
+		if fs.SizeInBytes != nil {
+			tmp.SizeInBytes = aws.Int64(fs.SizeInBytes.Value)
+		}
+
+		mountTargets, err := e.GetMountTargets(*fs.FileSystemId)
+		if err != nil {
+			logrus.Error(err.Error())
+			ch <- nil
+			return
+		}
+
+		var subnets []string
+		for _, mt := range mountTargets {
+			subnets = append(subnets, *mt.SubnetId)
+		}
+		tmp.MountTargetCount = aws.Int(len(mountTargets))
+		tmp.MountTargetSubnets = aws.String(strings.Join(subnets, constants.DefaultDelimiter))
+
+		accessPoints, err := e.GetAccessPoints(*fs.FileSystemId, nil, nil)
+		if err != nil {
+			logrus.Error(err.Error())
+			ch <- nil
+			return
+		}
+		tmp.AccessPointCount = aws.Int(len(accessPoints))
+
+		backupPolicy, err := e.GetBackupPolicy(*fs.FileSystemId)
+		if err != nil {
+			logrus.Error(err.Error())
+			ch <- nil
+			return
+		}
+		tmp.BackupPolicy = backupPolicy
+
+		logrus.Tracef("EFS file system is added: %s", *tmp.FileSystemID)
+		ch <- &tmp
+	}
+
+	logrus.Debugf("EFS file systems found: %d", len(fileSystems))
+	for _, fs := range fileSystems {
+		wg.Add(1)
+		go f(fs, input)
+	}
+
+	wg.Wait()
+	close(input)
+
+	result = <-output
+	logrus.Debugf("total valid EFS data count: %d", len(result))
+
+	return result, nil
+}
+
+// GetFileSystemList returns all EFS file systems in the region
+func (e *EFSClient) GetFileSystemList(original []types.FileSystemDescription, marker *string) ([]types.FileSystemDescription, error) {
+	result, err := e.Client.DescribeFileSystems(context.TODO(), &efs.DescribeFileSystemsInput{
+		Marker: marker,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	original = append(original, result.FileSystems...)
+	if result.NextMarker != nil {
+		return e.GetFileSystemList(original, result.NextMarker)
+	}
+	return original, nil
+}
+
+// GetMountTargets returns mount targets of file system
+func (e *EFSClient) GetMountTargets(fileSystemID string) ([]types.MountTargetDescription, error) {
+	result, err := e.Client.DescribeMountTargets(context.TODO(), &efs.DescribeMountTargetsInput{
+		FileSystemId: aws.String(fileSystemID),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result.MountTargets, nil
+}
+
+// GetAccessPoints returns access points of file system
+func (e *EFSClient) GetAccessPoints(fileSystemID string, original []types.AccessPointDescription, nextToken *string) ([]types.AccessPointDescription, error) {
+	result, err := e.Client.DescribeAccessPoints(context.TODO(), &efs.DescribeAccessPointsInput{
+		FileSystemId: aws.String(fileSystemID),
+		NextToken:    nextToken,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	original = append(original, result.AccessPoints...)
+	if result.NextToken != nil {
+		return e.GetAccessPoints(fileSystemID, original, result.NextToken)
+	}
+	return original, nil
+}
+
+// GetBackupPolicy returns status of automatic backup policy
+func (e *EFSClient) GetBackupPolicy(fileSystemID string) (*string, error) {
+	result, err := e.Client.DescribeBackupPolicy(context.TODO(), &efs.DescribeBackupPolicyInput{
+		FileSystemId: aws.String(fileSystemID),
+	})
+	if err != nil {
+		var notFound *types.PolicyNotFound
+		if errors.As(err, &notFound) {
+			return aws.String(string(types.StatusDisabled)), nil
+		}
+		return nil, err
+	}
+
+	if result.BackupPolicy == nil {
+		return aws.String(string(types.StatusDisabled)), nil
+	}
+
+	return aws.String(string(result.BackupPolicy.Status)), nil
+}
+
+// SetAlias sets alias
+func (e *EFSClient) SetAlias(alias *string) {
+	e.Alias = alias
+}
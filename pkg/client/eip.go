@@ -0,0 +1,93 @@
+/*
+Copyright 2020 The redhawk Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/sirupsen/logrus"
+
+	"github.com/DevopsArtFactory/redhawk/pkg/constants"
+	"github.com/DevopsArtFactory/redhawk/pkg/resource"
+)
+
+type EIPClient struct {
+	Resource string
+	Client   *ec2.Client
+	Region   string
+	Alias    *string
+}
+
+// GetResourceName returns resource name of client
+func (e *EIPClient) GetResourceName() string {
+	return e.Resource
+}
+
+// NewEIPClient creates a EIPClient
+func NewEIPClient(cfg aws.Config, helper Helper) (Client, error) {
+	return &EIPClient{
+		Resource: constants.EIPResourceName,
+		Client:   GetEC2ClientFn(cfg),
+		Region:   helper.Region,
+	}, nil
+}
+
+// Scan scans all data
+func (e *EIPClient) Scan() ([]resource.Resource, error) {
+	var result []resource.Resource
+
+	logrus.Debug("Start scanning all elastic IPs in the region")
+	addresses, err := e.GetAddresses()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, address := range addresses {
+		result = append(result, resource.EIPResource{
+			ResourceType:       aws.String(constants.EIPResourceName),
+			AllocationID:       address.AllocationId,
+			PublicIP:           address.PublicIp,
+			Region:             aws.String(e.Region),
+			Domain:             aws.String(string(address.Domain)),
+			AssociationID:      address.AssociationId,
+			InstanceID:         address.InstanceId,
+			NetworkInterfaceID: address.NetworkInterfaceId,
+			Tags:               ec2Tags(address.Tags),
+		})
+	}
+	logrus.Debugf("total elastic IP count: %d", len(result))
+
+	return result, nil
+}
+
+// GetAddresses returns all elastic IPs in the region
+func (e *EIPClient) GetAddresses() ([]types.Address, error) {
+	result, err := e.Client.DescribeAddresses(context.TODO(), &ec2.DescribeAddressesInput{})
+	if err != nil {
+		return nil, err
+	}
+
+	return result.Addresses, nil
+}
+
+// SetAlias sets alias
+func (e *EIPClient) SetAlias(alias *string) {
+	e.Alias = alias
+}
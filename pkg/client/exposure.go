@@ -0,0 +1,394 @@
+/*
+Copyright 2020 The redhawk Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2Types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	elbv2 "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2"
+	elbv2Types "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2/types"
+	"github.com/sirupsen/logrus"
+
+	"github.com/DevopsArtFactory/redhawk/pkg/constants"
+	"github.com/DevopsArtFactory/redhawk/pkg/resource"
+)
+
+type ExposureClient struct {
+	Resource string
+	EC2      *EC2Client
+	SG       *SGClient
+	RDS      *RDSClient
+	ELB      *LoadBalancerClient
+	Region   string
+	Alias    *string
+}
+
+// ExposureTarget is a resource which may be reachable from the internet
+type ExposureTarget struct {
+	TargetType     string
+	ID             string
+	Name           string
+	VpcID          string
+	PublicAddress  string
+	Subnets        []string
+	SecurityGroups []string
+
+	// Ports served by the target. Ports open in security groups are used if empty
+	Ports []PortRange
+}
+
+// GetResourceName returns resource name of client
+func (e *ExposureClient) GetResourceName() string {
+	return e.Resource
+}
+
+// NewExposureClient creates a ExposureClient
+func NewExposureClient(cfg aws.Config, helper Helper) (Client, error) {
+	ec2Client := GetEC2ClientFn(cfg)
+	return &ExposureClient{
+		Resource: constants.ExposureResourceName,
+		EC2: &EC2Client{
+			Resource: constants.EC2ResourceName,
+			Client:   ec2Client,
+			Region:   helper.Region,
+		},
+		SG: &SGClient{
+			Resource: constants.SGResourceName,
+			Client:   ec2Client,
+		},
+		RDS: &RDSClient{
+			Resource: constants.RDSResourceName,
+			Client:   GetRDSClientFn(cfg),
+		},
+		ELB: &LoadBalancerClient{
+			Resource: constants.LoadBalancerResourceName,
+			Client:   GetELBv2ClientFn(cfg),
+			Region:   helper.Region,
+		},
+		Region: helper.Region,
+	}, nil
+}
+
+// Scan scans all data
+func (e *ExposureClient) Scan() ([]resource.Resource, error) {
+	var result []resource.Resource
+
+	logrus.Debug("Start analyzing internet exposure in the region")
+	index, err := e.GetNetworkIndex()
+	if err != nil {
+		return nil, err
+	}
+
+	var targets []ExposureTarget
+	for _, f := range []func() ([]ExposureTarget, error){e.GetInstanceTargets, e.GetLoadBalancerTargets, e.GetDBInstanceTargets} {
+		t, err := f()
+		if err != nil {
+			return nil, err
+		}
+		targets = append(targets, t...)
+	}
+	logrus.Debugf("targets with public address found: %d", len(targets))
+
+	for _, target := range targets {
+		if tmp := AnalyzeExposure(target, index); tmp != nil {
+			tmp.Region = aws.String(e.Region)
+			result = append(result, *tmp)
+		}
+	}
+	logrus.Debugf("total exposed resource count: %d", len(result))
+
+	return result, nil
+}
+
+// SetAlias sets alias
+func (e *ExposureClient) SetAlias(alias *string) {
+	e.Alias = alias
+}
+
+// GetNetworkIndex gathers route tables, network ACLs and security groups
+func (e *ExposureClient) GetNetworkIndex() (*NetworkIndex, error) {
+	routeTables, err := e.GetRouteTables(nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	networkACLs, err := e.GetNetworkACLs(nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	securityGroups, err := e.SG.GetSGList(nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewNetworkIndex(routeTables, networkACLs, securityGroups), nil
+}
+
+// GetRouteTables returns all route tables in the region
+func (e *ExposureClient) GetRouteTables(original []ec2Types.RouteTable, nextToken *string) ([]ec2Types.RouteTable, error) {
+	result, err := e.EC2.Client.DescribeRouteTables(context.TODO(), &ec2.DescribeRouteTablesInput{
+		NextToken: nextToken,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	original = append(original, result.RouteTables...)
+	if result.NextToken != nil {
+		return e.GetRouteTables(original, result.NextToken)
+	}
+	return original, nil
+}
+
+// GetNetworkACLs returns all network ACLs in the region
+func (e *ExposureClient) GetNetworkACLs(original []ec2Types.NetworkAcl, nextToken *string) ([]ec2Types.NetworkAcl, error) {
+	result, err := e.EC2.Client.DescribeNetworkAcls(context.TODO(), &ec2.DescribeNetworkAclsInput{
+		NextToken: nextToken,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	original = append(original, result.NetworkAcls...)
+	if result.NextToken != nil {
+		return e.GetNetworkACLs(original, result.NextToken)
+	}
+	return original, nil
+}
+
+// GetInstanceTargets returns running instances with public IP
+func (e *ExposureClient) GetInstanceTargets() ([]ExposureTarget, error) {
+	reservations, err := e.EC2.GetEC2Instances(nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var ret []ExposureTarget
+	for _, r := range reservations {
+		for _, instance := range r.Instances {
+			if instance.PublicIpAddress == nil || instance.SubnetId == nil {
+				continue
+			}
+
+			if instance.State != nil && instance.State.Name != ec2Types.InstanceStateNameRunning {
+				continue
+			}
+
+			t := ExposureTarget{
+				TargetType:    constants.EC2ResourceName,
+				ID:            aws.ToString(instance.InstanceId),
+				VpcID:         aws.ToString(instance.VpcId),
+				PublicAddress: *instance.PublicIpAddress,
+				Subnets:       []string{*instance.SubnetId},
+			}
+
+			for _, tag := range instance.Tags {
+				if aws.ToString(tag.Key) == "Name" {
+					t.Name = aws.ToString(tag.Value)
+				}
+			}
+
+			for _, g := range instance.SecurityGroups {
+				t.SecurityGroups = append(t.SecurityGroups, aws.ToString(g.GroupId))
+			}
+
+			ret = append(ret, t)
+		}
+	}
+
+	return ret, nil
+}
+
+// GetLoadBalancerTargets returns internet-facing load balancers with their listener ports
+func (e *ExposureClient) GetLoadBalancerTargets() ([]ExposureTarget, error) {
+	loadBalancers, err := e.ELB.GetLoadBalancers(nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var ret []ExposureTarget
+	for _, lb := range loadBalancers {
+		if lb.Scheme != elbv2Types.LoadBalancerSchemeEnumInternetFacing {
+			continue
+		}
+
+		t := ExposureTarget{
+			TargetType:     constants.LoadBalancerTargetType,
+			ID:             aws.ToString(lb.LoadBalancerArn),
+			Name:           aws.ToString(lb.LoadBalancerName),
+			VpcID:          aws.ToString(lb.VpcId),
+			PublicAddress:  aws.ToString(lb.DNSName),
+			SecurityGroups: lb.SecurityGroups,
+		}
+
+		for _, az := range lb.AvailabilityZones {
+			if az.SubnetId != nil {
+				t.Subnets = append(t.Subnets, *az.SubnetId)
+			}
+		}
+
+		listeners, err := e.GetListeners(*lb.LoadBalancerArn, nil, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, l := range listeners {
+			if l.Port == nil {
+				continue
+			}
+
+			protocol := "tcp"
+			if l.Protocol == elbv2Types.ProtocolEnumUdp {
+				protocol = "udp"
+			}
+			t.Ports = append(t.Ports, PortRange{Protocol: protocol, From: int(*l.Port), To: int(*l.Port)})
+		}
+
+		if len(t.Ports) > 0 {
+			ret = append(ret, t)
+		}
+	}
+
+	return ret, nil
+}
+
+// GetListeners returns listeners of the load balancer
+func (e *ExposureClient) GetListeners(arn string, original []elbv2Types.Listener, marker *string) ([]elbv2Types.Listener, error) {
+	result, err := e.ELB.Client.DescribeListeners(context.TODO(), &elbv2.DescribeListenersInput{
+		LoadBalancerArn: aws.String(arn),
+		Marker:          marker,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	original = append(original, result.Listeners...)
+	if result.NextMarker != nil {
+		return e.GetListeners(arn, original, result.NextMarker)
+	}
+	return original, nil
+}
+
+// GetDBInstanceTargets returns publicly accessible DB instances
+func (e *ExposureClient) GetDBInstanceTargets() ([]ExposureTarget, error) {
+	instances, err := e.RDS.GetDBInstances(nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var ret []ExposureTarget
+	for _, db := range instances {
+		if !db.PubliclyAccessible || db.Endpoint == nil || db.DBSubnetGroup == nil {
+			continue
+		}
+
+		t := ExposureTarget{
+			TargetType:    constants.RDSResourceName,
+			ID:            aws.ToString(db.DBInstanceIdentifier),
+			Name:          aws.ToString(db.DBInstanceIdentifier),
+			VpcID:         aws.ToString(db.DBSubnetGroup.VpcId),
+			PublicAddress: aws.ToString(db.Endpoint.Address),
+			Ports:         []PortRange{{Protocol: "tcp", From: int(db.Endpoint.Port), To: int(db.Endpoint.Port)}},
+		}
+
+		for _, s := range db.DBSubnetGroup.Subnets {
+			t.Subnets = append(t.Subnets, aws.ToString(s.SubnetIdentifier))
+		}
+
+		for _, g := range db.VpcSecurityGroups {
+			t.SecurityGroups = append(t.SecurityGroups, aws.ToString(g.VpcSecurityGroupId))
+		}
+
+		ret = append(ret, t)
+	}
+
+	return ret, nil
+}
+
+// AnalyzeExposure checks if the target is reachable from the internet.
+// The target needs a subnet with internet gateway route, and ports allowed by both security groups and network ACL.
+// nil is returned if the target is not exposed.
+func AnalyzeExposure(t ExposureTarget, index *NetworkIndex) *resource.ExposureResource {
+	var routed []string
+	for _, subnet := range t.Subnets {
+		if index.HasInternetRoute(t.VpcID, subnet) {
+			routed = append(routed, subnet)
+		}
+	}
+
+	if len(routed) == 0 {
+		return nil
+	}
+
+	var open []PortRange
+	switch {
+	case len(t.SecurityGroups) == 0:
+		// Network load balancers without security group accept all traffic to listeners
+		open = t.Ports
+	case len(t.Ports) == 0:
+		open = index.WorldOpenPorts(t.SecurityGroups)
+	default:
+		worldOpen := index.WorldOpenPorts(t.SecurityGroups)
+		for _, p := range t.Ports {
+			for _, w := range worldOpen {
+				if w.Contains(p.Protocol, p.From) {
+					open = append(open, p)
+					break
+				}
+			}
+		}
+	}
+
+	reachable := index.ReachablePorts(t.VpcID, routed, open)
+	if len(reachable) == 0 {
+		return nil
+	}
+
+	var ports, sensitive []string
+	for _, p := range reachable {
+		ports = append(ports, p.String())
+		if s := sensitivePorts(p.Protocol, p.From, p.To); len(s) > 0 {
+			sensitive = append(sensitive, s)
+		}
+	}
+
+	tmp := resource.ExposureResource{
+		ResourceType:   aws.String(constants.ExposureResourceName),
+		TargetType:     aws.String(t.TargetType),
+		TargetID:       aws.String(t.ID),
+		VpcID:          aws.String(t.VpcID),
+		PublicAddress:  aws.String(t.PublicAddress),
+		Subnets:        aws.String(strings.Join(routed, constants.DefaultDelimiter)),
+		SecurityGroups: aws.String(strings.Join(t.SecurityGroups, constants.DefaultDelimiter)),
+		ExposedPorts:   aws.String(strings.Join(ports, constants.DefaultDelimiter)),
+	}
+
+	if len(t.Name) > 0 {
+		tmp.Name = aws.String(t.Name)
+	}
+
+	if len(sensitive) > 0 {
+		tmp.SensitivePorts = aws.String(strings.Join(uniqueSorted(strings.Split(strings.Join(sensitive, constants.DefaultDelimiter), constants.DefaultDelimiter)), constants.DefaultDelimiter))
+	}
+
+	return &tmp
+}
@@ -0,0 +1,129 @@
+/*
+Copyright 2020 The redhawk Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+)
+
+func TestAnalyzeExposure(t *testing.T) {
+	routeTables := []types.RouteTable{
+		{
+			VpcId:        aws.String("vpc-1"),
+			Associations: []types.RouteTableAssociation{{Main: aws.Bool(true)}},
+			Routes:       []types.Route{{DestinationCidrBlock: aws.String("10.0.0.0/16"), GatewayId: aws.String("local")}},
+		},
+		{
+			VpcId:        aws.String("vpc-1"),
+			Associations: []types.RouteTableAssociation{{SubnetId: aws.String("subnet-public")}},
+			Routes: []types.Route{
+				{DestinationCidrBlock: aws.String("0.0.0.0/0"), GatewayId: aws.String("igw-1"), State: types.RouteStateActive},
+			},
+		},
+	}
+
+	entry := func(number int32, action types.RuleAction, from, to int32) types.NetworkAclEntry {
+		return types.NetworkAclEntry{
+			RuleNumber: aws.Int32(number),
+			CidrBlock:  aws.String("0.0.0.0/0"),
+			Egress:     aws.Bool(false),
+			Protocol:   aws.String("6"),
+			PortRange:  &types.PortRange{From: aws.Int32(from), To: aws.Int32(to)},
+			RuleAction: action,
+		}
+	}
+	networkACLs := []types.NetworkAcl{
+		{
+			VpcId:        aws.String("vpc-1"),
+			IsDefault:    aws.Bool(true),
+			Associations: []types.NetworkAclAssociation{{SubnetId: aws.String("subnet-public")}},
+			Entries: []types.NetworkAclEntry{
+				entry(100, types.RuleActionDeny, 3389, 3389),
+				entry(200, types.RuleActionAllow, 0, 65535),
+			},
+		},
+	}
+
+	open := func(from, to int32) types.IpPermission {
+		return types.IpPermission{
+			IpProtocol: aws.String("tcp"),
+			FromPort:   aws.Int32(from),
+			ToPort:     aws.Int32(to),
+			IpRanges:   []types.IpRange{{CidrIp: aws.String("0.0.0.0/0")}},
+		}
+	}
+	securityGroups := []types.SecurityGroup{
+		{GroupId: aws.String("sg-1"), IpPermissions: []types.IpPermission{open(22, 22), open(3389, 3389)}},
+		{GroupId: aws.String("sg-2"), IpPermissions: []types.IpPermission{open(443, 443)}},
+	}
+
+	index := NewNetworkIndex(routeTables, networkACLs, securityGroups)
+
+	tests := []struct {
+		name      string
+		target    ExposureTarget
+		ports     string
+		sensitive string
+	}{
+		{
+			name:      "instance in public subnet",
+			target:    ExposureTarget{VpcID: "vpc-1", Subnets: []string{"subnet-public"}, SecurityGroups: []string{"sg-1"}},
+			ports:     "22",
+			sensitive: "22(SSH)",
+		},
+		{
+			name:   "instance in private subnet",
+			target: ExposureTarget{VpcID: "vpc-1", Subnets: []string{"subnet-private"}, SecurityGroups: []string{"sg-1"}},
+		},
+		{
+			name:   "load balancer listener allowed by security group",
+			target: ExposureTarget{VpcID: "vpc-1", Subnets: []string{"subnet-private", "subnet-public"}, SecurityGroups: []string{"sg-2"}, Ports: []PortRange{{"tcp", 443, 443}, {"tcp", 8080, 8080}}},
+			ports:  "443",
+		},
+		{
+			name:   "load balancer without security group",
+			target: ExposureTarget{VpcID: "vpc-1", Subnets: []string{"subnet-public"}, Ports: []PortRange{{"tcp", 8080, 8080}}},
+			ports:  "8080",
+		},
+		{
+			name:   "database port closed by security group",
+			target: ExposureTarget{VpcID: "vpc-1", Subnets: []string{"subnet-public"}, SecurityGroups: []string{"sg-2"}, Ports: []PortRange{{"tcp", 5432, 5432}}},
+		},
+	}
+
+	for _, test := range tests {
+		exposure := AnalyzeExposure(test.target, index)
+		if len(test.ports) == 0 {
+			if exposure != nil {
+				t.Errorf("%s: expected no exposure, got %s", test.name, *exposure.ExposedPorts)
+			}
+			continue
+		}
+
+		if exposure == nil {
+			t.Errorf("%s: expected exposure", test.name)
+			continue
+		}
+
+		if *exposure.ExposedPorts != test.ports || aws.ToString(exposure.SensitivePorts) != test.sensitive {
+			t.Errorf("%s: unexpected ports %s / %s", test.name, *exposure.ExposedPorts, aws.ToString(exposure.SensitivePorts))
+		}
+	}
+}
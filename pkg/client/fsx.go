@@ -0,0 +1,144 @@
+/*
+Copyright 2020 The redhawk Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/fsx"
+	"github.com/aws/aws-sdk-go-v2/service/fsx/types"
+	"github.com/sirupsen/logrus"
+
+	"github.com/DevopsArtFactory/redhawk/pkg/constants"
+	"github.com/DevopsArtFactory/redhawk/pkg/resource"
+)
+
+type FSxClient struct {
+	Resource string
+	Client   *fsx.Client
+	Region   string
+	Alias    *string
+}
+
+// GetResourceName returns resource name of client
+func (f *FSxClient) GetResourceName() string {
+	return f.Resource
+}
+
+// NewFSxClient creates a FSxClient
+func NewFSxClient(cfg aws.Config, helper Helper) (Client, error) {
+	return &FSxClient{
+		Resource: constants.FSxResourceName,
+		Client:   GetFSxClientFn(cfg),
+		Region:   helper.Region,
+	}, nil
+}
+
+// GetFSxClientFn creates fsx client
+func GetFSxClientFn(cfg aws.Config) *fsx.Client {
+	return fsx.NewFromConfig(cfg)
+}
+
+// Scan scans all data
+func (f *FSxClient) Scan() ([]resource.Resource, error) {
+	var result []resource.Resource
+
+	logrus.Debug("Start scanning all FSx file systems in the region")
+	fileSystems, err := f.GetFileSystemList(nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(fileSystems) == 0 {
+		logrus.Debug("no FSx file system found")
+		return nil, nil
+	}
+
+	for _, fs := range fileSystems {
+		tmp := resource.FSxResource{
+			ResourceType: aws.String(constants.FSxResourceName),
+		}
+
+		tmp.FileSystemID = fs.FileSystemId
+		tmp.FileSystemType = aws.String(string(fs.FileSystemType))
+		tmp.Region = aws.String(f.Region)
+		tmp.Lifecycle = aws.String(string(fs.Lifecycle))
+		tmp.StorageType = aws.String(string(fs.StorageType))
+		tmp.KmsKeyID = fs.KmsKeyId
+		tmp.VpcID = fs.VpcId
+		tmp.SubnetIDs = aws.String(strings.Join(fs.SubnetIds, constants.DefaultDelimiter))
+		tmp.Created = fs.CreationTime
+
+		tmp.Tags = resource.Tags{}
+		for _, tag := range fs.Tags {
+			tmp.Tags[aws.ToString(tag.Key)] = aws.ToString(tag.Value)
+		}
+
+		if fs.StorageCapacity != nil {
+			tmp.StorageCapacity = aws.Int(int(*fs.StorageCapacity))
+		}
+
+		if retention := getFSxBackupRetentionDays(fs); retention != nil {
+			tmp.BackupRetentionDays = aws.Int(int(*retention))
+		}
+
+		result = append(result, tmp)
+	}
+
+	logrus.Debugf("total valid FSx data count: %d", len(result))
+
+	return result, nil
+}
+
+// GetFileSystemList returns all FSx file systems in the region
+func (f *FSxClient) GetFileSystemList(original []types.FileSystem, nextToken *string) ([]types.FileSystem, error) {
+	result, err := f.Client.DescribeFileSystems(context.TODO(), &fsx.DescribeFileSystemsInput{
+		NextToken: nextToken,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	original = append(original, result.FileSystems...)
+	if result.NextToken != nil {
+		return f.GetFileSystemList(original, result.NextToken)
+	}
+	return original, nil
+}
+
+// SetAlias sets alias
+func (f *FSxClient) SetAlias(alias *string) {
+	f.Alias = alias
+}
+
+// getFSxBackupRetentionDays returns automatic backup retention from type specific configuration
+func getFSxBackupRetentionDays(fs types.FileSystem) *int32 {
+	switch {
+	case fs.WindowsConfiguration != nil:
+		return fs.WindowsConfiguration.AutomaticBackupRetentionDays
+	case fs.LustreConfiguration != nil:
+		return fs.LustreConfiguration.AutomaticBackupRetentionDays
+	case fs.OntapConfiguration != nil:
+		return fs.OntapConfiguration.AutomaticBackupRetentionDays
+	case fs.OpenZFSConfiguration != nil:
+		return fs.OpenZFSConfiguration.AutomaticBackupRetentionDays
+	}
+
+	return nil
+}
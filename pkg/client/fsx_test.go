@@ -0,0 +1,63 @@
+/*
+Copyright 2020 The redhawk Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/fsx/types"
+)
+
+func TestGetFSxBackupRetentionDays(t *testing.T) {
+	tests := []struct {
+		description string
+		fs          types.FileSystem
+		expected    *int32
+	}{
+		{
+			description: "windows",
+			fs:          types.FileSystem{WindowsConfiguration: &types.WindowsFileSystemConfiguration{AutomaticBackupRetentionDays: aws.Int32(7)}},
+			expected:    aws.Int32(7),
+		},
+		{
+			description: "lustre without backup",
+			fs:          types.FileSystem{LustreConfiguration: &types.LustreFileSystemConfiguration{AutomaticBackupRetentionDays: aws.Int32(0)}},
+			expected:    aws.Int32(0),
+		},
+		{
+			description: "ontap",
+			fs:          types.FileSystem{OntapConfiguration: &types.OntapFileSystemConfiguration{AutomaticBackupRetentionDays: aws.Int32(30)}},
+			expected:    aws.Int32(30),
+		},
+		{
+			description: "openzfs",
+			fs:          types.FileSystem{OpenZFSConfiguration: &types.OpenZFSFileSystemConfiguration{AutomaticBackupRetentionDays: aws.Int32(1)}},
+			expected:    aws.Int32(1),
+		},
+		{
+			description: "no configuration",
+		},
+	}
+
+	for _, test := range tests {
+		got := getFSxBackupRetentionDays(test.fs)
+		if (got == nil) != (test.expected == nil) || (got != nil && *got != *test.expected) {
+			t.Errorf("%s: expected %v, got %v", test.description, aws.ToInt32(test.expected), aws.ToInt32(got))
+		}
+	}
+}
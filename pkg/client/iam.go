@@ -18,11 +18,8 @@ package client
 
 import (
 	"context"
-	"encoding/json"
-	"reflect"
 	"strings"
 	"sync"
-	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/iam"
@@ -31,13 +28,13 @@ import (
 
 	"github.com/DevopsArtFactory/redhawk/pkg/constants"
 	"github.com/DevopsArtFactory/redhawk/pkg/resource"
-	"github.com/DevopsArtFactory/redhawk/pkg/tools"
 )
 
 type IAMClient struct {
 	Resource string
 	Alias    *string
 	Client   *iam.Client
+	Config   aws.Config
 }
 
 type PolicyDocument struct {
@@ -46,14 +43,21 @@ type PolicyDocument struct {
 }
 
 type StatementEntry struct {
-	Effect    string
-	Principal *Principal
-	Resource  interface{}
+	Sid         string
+	Effect      string
+	Principal   *Principal
+	Action      interface{}
+	NotAction   interface{}
+	Resource    interface{}
+	NotResource interface{}
+	Condition   map[string]map[string]interface{}
 }
 
 type Principal struct {
-	Service   interface{}
-	Federated interface{}
+	AWS           interface{}
+	Service       interface{}
+	Federated     interface{}
+	CanonicalUser interface{}
 }
 
 // GetResourceName returns resource name of client
@@ -66,6 +70,7 @@ func NewIAMClient(cfg aws.Config, _ Helper) (Client, error) {
 	return &IAMClient{
 		Resource: constants.IAMResourceName,
 		Client:   GetIAMClientFn(cfg),
+		Config:   cfg,
 	}, nil
 }
 
@@ -87,16 +92,43 @@ func (i *IAMClient) Scan() ([]resource.Resource, error) {
 		result = append(result, groupData...)
 	}
 
-	userData, err := i.ScanUser(userMapList)
+	logrus.Debug("Start scanning all IAM user list in the account")
+	userList, err := i.GetUserList()
+	if err != nil {
+		return nil, err
+	}
+
+	logrus.Debug("Generate IAM credential report")
+	report, err := i.GetCredentialReport()
 	if err != nil {
 		return nil, err
 	}
 
+	userData := i.ScanUser(userList, report, userMapList)
 	if userData != nil {
 		result = append(result, userData...)
 	}
 
-	roleData, err := i.ScanRole()
+	accessKeyData := i.ScanAccessKey(userList, report)
+	if accessKeyData != nil {
+		result = append(result, accessKeyData...)
+	}
+
+	details, err := i.GetAuthorizationDetails()
+	if err != nil {
+		return nil, err
+	}
+
+	// ListUsers does not return tags of users
+	for _, d := range userData {
+		if user, ok := d.(*resource.IAMUserResource); ok {
+			if detail, ok := details.Users[*user.UserName]; ok {
+				user.Tags = iamTags(detail.Tags)
+			}
+		}
+	}
+
+	roleData, err := i.ScanRole(details)
 	if err != nil {
 		return nil, err
 	}
@@ -105,6 +137,11 @@ func (i *IAMClient) Scan() ([]resource.Resource, error) {
 		result = append(result, roleData...)
 	}
 
+	policyData := i.ScanPolicy(details)
+	if policyData != nil {
+		result = append(result, policyData...)
+	}
+
 	return result, nil
 }
 
@@ -133,15 +170,21 @@ func (i *IAMClient) GetGroupList() ([]types.Group, error) {
 }
 
 // GetRoleList returns all IAM role list
-func (i *IAMClient) GetRoleList() ([]types.Role, error) {
-	input := &iam.ListRolesInput{}
+func (i *IAMClient) GetRoleList(original []types.Role, marker *string) ([]types.Role, error) {
+	input := &iam.ListRolesInput{
+		Marker: marker,
+	}
 
 	result, err := i.Client.ListRoles(context.TODO(), input)
 	if err != nil {
 		return nil, err
 	}
 
-	return result.Roles, nil
+	original = append(original, result.Roles...)
+	if result.IsTruncated {
+		return i.GetRoleList(original, result.Marker)
+	}
+	return original, nil
 }
 
 // GetAccessKeys returns all access keys of user
@@ -323,22 +366,11 @@ func (i *IAMClient) ScanGroup() ([]resource.Resource, map[string][]string, error
 	return result, userGroupMap, nil
 }
 
-// ScanUser scans all IAM group
-func (i *IAMClient) ScanUser(userGroupMap map[string][]string) ([]resource.Resource, error) {
+// ScanUser scans all IAM user with IAM credential report
+func (i *IAMClient) ScanUser(userList []types.User, report map[string]CredentialReportRow, userGroupMap map[string][]string) []resource.Resource {
 	var wg sync.WaitGroup
 	var result []resource.Resource
 
-	logrus.Debug("Start scanning all IAM user list in the account")
-	userList, err := i.GetUserList()
-	if err != nil {
-		return nil, err
-	}
-
-	if len(userList) == 0 {
-		logrus.Debug("no IAM user found")
-		return nil, nil
-	}
-
 	input := make(chan *resource.IAMUserResource)
 	output := make(chan []resource.Resource)
 	defer close(output)
@@ -363,13 +395,6 @@ func (i *IAMClient) ScanUser(userGroupMap map[string][]string) ([]resource.Resou
 		tmp.UserName = user.UserName
 		tmp.UserCreated = user.CreateDate
 
-		accessKeys, err := i.GetAccessKeys(*user.UserName)
-		if err != nil {
-			logrus.Errorf(err.Error())
-			ch <- nil
-			return
-		}
-
 		devices, err := i.GetMFADevices(*user.UserName)
 		if err != nil {
 			logrus.Errorf(err.Error())
@@ -388,24 +413,11 @@ func (i *IAMClient) ScanUser(userGroupMap map[string][]string) ([]resource.Resou
 			tmp.MFA = aws.String(strings.Join(mfaDevices, constants.DefaultDelimiter))
 		}
 
-		var lastlyUsed *time.Time
-		for _, ak := range accessKeys {
-			lastUsed, err := i.GetLastAccessKeyUsed(ak.AccessKeyId)
-			if err != nil {
-				logrus.Errorf(err.Error())
-				ch <- nil
-				return
-			}
-
-			if lastUsed.LastUsedDate == nil {
-				continue
-			}
-
-			if lastlyUsed == nil || lastUsed.LastUsedDate.Sub(*lastlyUsed) > 0 {
-				lastlyUsed = lastUsed.LastUsedDate
-			}
+		if row, ok := report[*user.UserName]; ok {
+			setCredentialReport(&tmp, row)
+		} else {
+			logrus.Debugf("user is not in credential report: %s", *user.UserName)
 		}
-		tmp.AccessKeyLastUsed = lastlyUsed
 
 		if _, ok := userGroupMap[*user.UserName]; !ok {
 			tmp.GroupCount = aws.Int(0)
@@ -426,18 +438,75 @@ func (i *IAMClient) ScanUser(userGroupMap map[string][]string) ([]resource.Resou
 	close(input)
 
 	result = <-output
+
+	if row, ok := report[constants.RootAccountUser]; ok {
+		root := resource.IAMUserResource{
+			ResourceType: aws.String(constants.IAMUserResourceName),
+			UserName:     aws.String(constants.RootAccountUser),
+			UserCreated:  row.UserCreated,
+			GroupCount:   aws.Int(0),
+		}
+		setCredentialReport(&root, row)
+		result = append(result, &root)
+	}
+
 	logrus.Debugf("total valid IAM user data count: %d", len(result))
 
-	return result, nil
+	return result
+}
+
+// setCredentialReport fills user resource with a row of credential report
+func setCredentialReport(user *resource.IAMUserResource, row CredentialReportRow) {
+	user.PasswordEnabled = row.PasswordEnabled
+	user.PasswordLastChanged = row.PasswordLastChanged
+	user.PasswordAge = daysSince(row.PasswordLastChanged)
+	user.ConsoleLastLogin = row.PasswordLastUsed
+	user.MFAActive = row.MFAActive
+
+	key1, key2 := row.AccessKeys[0], row.AccessKeys[1]
+	user.AccessKey1Active = key1.Active
+	user.AccessKey1Age = daysSince(key1.LastRotated)
+	user.AccessKey1LastUsedService = key1.LastUsedService
+	user.AccessKey2Active = key2.Active
+	user.AccessKey2Age = daysSince(key2.LastRotated)
+	user.AccessKey2LastUsedService = key2.LastUsedService
+	user.AccessKeyLastUsed = latestTime(key1.LastUsed, key2.LastUsed)
+
+	// age of the oldest active access key
+	for _, key := range row.AccessKeys {
+		if key.Active == nil || !*key.Active {
+			continue
+		}
+
+		if age := daysSince(key.LastRotated); age != nil && (user.AccessKeyAge == nil || *age > *user.AccessKeyAge) {
+			user.AccessKeyAge = age
+		}
+	}
+
+	user.UserLastActivity = latestTime(row.PasswordLastUsed, key1.LastUsed, key2.LastUsed)
+}
+
+// iamTags converts tags of IAM resources
+func iamTags(tags []types.Tag) resource.Tags {
+	ret := resource.Tags{}
+	for _, tag := range tags {
+		ret[aws.ToString(tag.Key)] = aws.ToString(tag.Value)
+	}
+	return ret
 }
 
-// ScanRole scans all IAM group
-func (i *IAMClient) ScanRole() ([]resource.Resource, error) {
+// ScanRole scans all IAM role
+func (i *IAMClient) ScanRole(details *AuthorizationDetails) ([]resource.Resource, error) {
 	var wg sync.WaitGroup
 	var result []resource.Resource
 
 	logrus.Debug("Start scanning all IAM role list in the account")
-	roleList, err := i.GetRoleList()
+	roleList, err := i.GetRoleList(nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	trustContext, err := i.GetTrustContext()
 	if err != nil {
 		return nil, err
 	}
@@ -469,65 +538,44 @@ func (i *IAMClient) ScanRole() ([]resource.Resource, error) {
 		}
 
 		tmp.RoleName = role.RoleName
+		tmp.Created = role.CreateDate
 		if role.RoleLastUsed != nil {
 			tmp.RoleLastActivity = role.RoleLastUsed.LastUsedDate
 		}
 
-		var pd PolicyDocument
-
-		var stringPolicy string
-		if strings.HasPrefix(*role.AssumeRolePolicyDocument, "%") {
-			stringPolicy, err = tools.DecodeURLEncodedString(*role.AssumeRolePolicyDocument)
-			if err != nil {
-				logrus.Errorf("policy parsing error: %s", *role.RoleName)
-				ch <- nil
-				return
-			}
-		} else {
-			stringPolicy = *role.AssumeRolePolicyDocument
-		}
-
-		err := json.Unmarshal([]byte(stringPolicy), &pd)
+		pd, err := ParsePolicyDocument(*role.AssumeRolePolicyDocument)
 		if err != nil {
-			logrus.Error(err.Error())
+			logrus.Errorf("policy parsing error: %s", *role.RoleName)
 			ch <- nil
 			return
 		}
 
-		var trustedEntities []string
-		for _, statement := range pd.Statement {
-			if statement.Principal != nil {
-				if statement.Principal.Service != nil {
-					sps := reflect.TypeOf(statement.Principal.Service).Name()
-					if sps != "string" {
-						// Service is a slice of string
-						serviceSlice := statement.Principal.Service.([]interface{})
-
-						for _, ss := range serviceSlice {
-							trustedEntities = append(trustedEntities, ss.(string))
-						}
-					} else {
-						trustedEntities = append(trustedEntities, statement.Principal.Service.(string))
-					}
-				}
-
-				if statement.Principal.Federated != nil {
-					spf := reflect.TypeOf(statement.Principal.Federated).Name()
-					if spf != "string" {
-						// Service is a slice of string
-						federatedSlice := statement.Principal.Federated.([]interface{})
-
-						for _, fs := range federatedSlice {
-							trustedEntities = append(trustedEntities, fs.(string))
-						}
-					} else {
-						trustedEntities = append(trustedEntities, statement.Principal.Federated.(string))
-					}
-				}
+		trust := AnalyzeTrustPolicy(pd, trustContext)
+		tmp.TrustedEntities = aws.String(strings.Join(trust.TrustedEntities, constants.DefaultDelimiter))
+		tmp.TrustClassification = aws.String(trust.Classification)
+		tmp.TrustedAccounts = aws.String(strings.Join(trust.ExternalAccounts, constants.DefaultDelimiter))
+		tmp.ExternalIDRequired = aws.Bool(trust.ExternalIDRequired)
+		tmp.TrustConditions = aws.String(strings.Join(trust.Conditions, constants.DefaultDelimiter))
+
+		if detail, ok := details.Roles[*role.RoleName]; ok {
+			var attached, inline []string
+			for _, policy := range detail.AttachedManagedPolicies {
+				attached = append(attached, *policy.PolicyName)
 			}
-		}
 
-		tmp.TrustedEntities = aws.String(strings.Join(trustedEntities, constants.DefaultDelimiter))
+			for _, policy := range detail.RolePolicyList {
+				inline = append(inline, *policy.PolicyName)
+			}
+
+			tmp.AttachedPolicies = aws.String(strings.Join(attached, constants.DefaultDelimiter))
+			tmp.InlinePolicies = aws.String(strings.Join(inline, constants.DefaultDelimiter))
+
+			// ListRoles does not return last usage and tags of roles
+			if tmp.RoleLastActivity == nil && detail.RoleLastUsed != nil {
+				tmp.RoleLastActivity = detail.RoleLastUsed.LastUsedDate
+			}
+			tmp.Tags = iamTags(detail.Tags)
+		}
 
 		ch <- &tmp
 	}
@@ -561,5 +609,9 @@ func GetAccountAlias(cfg aws.Config) (string, error) {
 		return constants.EmptyString, err
 	}
 
+	if len(result.AccountAliases) == 0 {
+		return constants.EmptyString, nil
+	}
+
 	return result.AccountAliases[0], nil
 }
@@ -0,0 +1,148 @@
+/*
+Copyright 2020 The redhawk Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/iam/types"
+	"github.com/sirupsen/logrus"
+
+	"github.com/DevopsArtFactory/redhawk/pkg/constants"
+	"github.com/DevopsArtFactory/redhawk/pkg/resource"
+)
+
+// ScanAccessKey scans all access keys of IAM users.
+// Usage of access key is found in credential report, or with API if key is not matched with the report
+func (i *IAMClient) ScanAccessKey(userList []types.User, report map[string]CredentialReportRow) []resource.Resource {
+	var wg sync.WaitGroup
+	var result []resource.Resource
+
+	logrus.Debug("Start scanning all IAM access keys in the account")
+
+	input := make(chan []resource.Resource)
+	output := make(chan []resource.Resource)
+	defer close(output)
+
+	go func(input chan []resource.Resource, output chan []resource.Resource, wg *sync.WaitGroup) {
+		var ret []resource.Resource
+		for result := range input {
+			ret = append(ret, result...)
+			wg.Done()
+		}
+
+		output <- ret
+	}(input, output, &wg)
+
+	f := func(user types.User, ch chan []resource.Resource) {
+		accessKeys, err := i.GetAccessKeys(*user.UserName)
+		if err != nil {
+			logrus.Errorf(err.Error())
+			ch <- nil
+			return
+		}
+
+		var ret []resource.Resource
+		for _, ak := range accessKeys {
+			tmp := resource.IAMAccessKeyResource{
+				ResourceType: aws.String(constants.IAMAccessKeyResourceName),
+			}
+
+			tmp.AccessKeyID = aws.String(maskAccessKeyID(*ak.AccessKeyId))
+			tmp.UserName = user.UserName
+			tmp.Status = aws.String(string(ak.Status))
+			tmp.Created = ak.CreateDate
+			tmp.AgeDays = daysSince(ak.CreateDate)
+
+			if key, ok := findReportAccessKey(report[*user.UserName], ak); ok {
+				tmp.LastUsed = key.LastUsed
+				tmp.LastUsedService = key.LastUsedService
+				tmp.LastUsedRegion = key.LastUsedRegion
+			} else {
+				lastUsed, err := i.GetLastAccessKeyUsed(ak.AccessKeyId)
+				if err != nil {
+					logrus.Errorf(err.Error())
+					continue
+				}
+
+				tmp.LastUsed = lastUsed.LastUsedDate
+				tmp.LastUsedService = parseReportString(aws.ToString(lastUsed.ServiceName))
+				tmp.LastUsedRegion = parseReportString(aws.ToString(lastUsed.Region))
+			}
+
+			ret = append(ret, tmp)
+		}
+
+		ch <- ret
+	}
+
+	for _, user := range userList {
+		wg.Add(1)
+		go f(user, input)
+	}
+
+	wg.Wait()
+	close(input)
+
+	result = <-output
+	logrus.Debugf("total valid IAM access key data count: %d", len(result))
+
+	return result
+}
+
+// findReportAccessKey finds access key in credential report with creation date.
+// Last rotated date of access key in the report is the creation date of the key in seconds.
+// Keys created in the same second are told apart with status, and are not matched if they are still ambiguous
+func findReportAccessKey(row CredentialReportRow, ak types.AccessKeyMetadata) (CredentialReportAccessKey, bool) {
+	if ak.CreateDate == nil {
+		return CredentialReportAccessKey{}, false
+	}
+
+	var matched []CredentialReportAccessKey
+	for _, key := range row.AccessKeys {
+		if key.LastRotated != nil && key.LastRotated.Unix() == ak.CreateDate.Unix() {
+			matched = append(matched, key)
+		}
+	}
+
+	if len(matched) > 1 {
+		active := ak.Status == types.StatusTypeActive
+		var same []CredentialReportAccessKey
+		for _, key := range matched {
+			if key.Active != nil && *key.Active == active {
+				same = append(same, key)
+			}
+		}
+		matched = same
+	}
+
+	if len(matched) != 1 {
+		return CredentialReportAccessKey{}, false
+	}
+	return matched[0], true
+}
+
+// maskAccessKeyID hides the middle of access key ID
+func maskAccessKeyID(id string) string {
+	if len(id) <= 8 {
+		return strings.Repeat("*", len(id))
+	}
+
+	return id[:4] + strings.Repeat("*", len(id)-8) + id[len(id)-4:]
+}
@@ -0,0 +1,180 @@
+/*
+Copyright 2020 The redhawk Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	"github.com/aws/aws-sdk-go-v2/service/iam/types"
+	"github.com/sirupsen/logrus"
+
+	"github.com/DevopsArtFactory/redhawk/pkg/constants"
+)
+
+// CredentialReportRow is a row of IAM credential report
+type CredentialReportRow struct {
+	User                string
+	UserCreated         *time.Time
+	PasswordEnabled     *bool
+	PasswordLastUsed    *time.Time
+	PasswordLastChanged *time.Time
+	MFAActive           *bool
+	AccessKeys          [2]CredentialReportAccessKey
+}
+
+// CredentialReportAccessKey is access key information of a user in IAM credential report
+type CredentialReportAccessKey struct {
+	Active          *bool
+	LastRotated     *time.Time
+	LastUsed        *time.Time
+	LastUsedRegion  *string
+	LastUsedService *string
+}
+
+// GetCredentialReport generates IAM credential report and returns rows by user name
+func (i *IAMClient) GetCredentialReport() (map[string]CredentialReportRow, error) {
+	for count := 0; ; count++ {
+		result, err := i.Client.GenerateCredentialReport(context.TODO(), &iam.GenerateCredentialReportInput{})
+		if err != nil {
+			return nil, err
+		}
+
+		if result.State == types.ReportStateTypeComplete {
+			break
+		}
+
+		if count >= constants.CredentialReportRetryCount {
+			return nil, fmt.Errorf("credential report is not generated: %s", result.State)
+		}
+
+		logrus.Debugf("wait for credential report generation: %s", result.State)
+		time.Sleep(constants.CredentialReportRetryInterval)
+	}
+
+	result, err := i.Client.GetCredentialReport(context.TODO(), &iam.GetCredentialReportInput{})
+	if err != nil {
+		return nil, err
+	}
+
+	return parseCredentialReport(result.Content)
+}
+
+// parseCredentialReport parses CSV content of IAM credential report
+func parseCredentialReport(content []byte) (map[string]CredentialReportRow, error) {
+	records, err := csv.NewReader(bytes.NewReader(content)).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(records) == 0 {
+		return nil, errors.New("credential report is empty")
+	}
+
+	index := map[string]int{}
+	for i, column := range records[0] {
+		index[column] = i
+	}
+
+	ret := map[string]CredentialReportRow{}
+	for _, record := range records[1:] {
+		get := func(column string) string {
+			i, ok := index[column]
+			if !ok || i >= len(record) {
+				return constants.EmptyString
+			}
+			return record[i]
+		}
+
+		row := CredentialReportRow{
+			User:                get("user"),
+			UserCreated:         parseReportTime(get("user_creation_time")),
+			PasswordEnabled:     parseReportBool(get("password_enabled")),
+			PasswordLastUsed:    parseReportTime(get("password_last_used")),
+			PasswordLastChanged: parseReportTime(get("password_last_changed")),
+			MFAActive:           parseReportBool(get("mfa_active")),
+		}
+
+		for k := range row.AccessKeys {
+			prefix := fmt.Sprintf("access_key_%d_", k+1)
+			row.AccessKeys[k] = CredentialReportAccessKey{
+				Active:          parseReportBool(get(prefix + "active")),
+				LastRotated:     parseReportTime(get(prefix + "last_rotated")),
+				LastUsed:        parseReportTime(get(prefix + "last_used_date")),
+				LastUsedRegion:  parseReportString(get(prefix + "last_used_region")),
+				LastUsedService: parseReportString(get(prefix + "last_used_service")),
+			}
+		}
+
+		ret[row.User] = row
+	}
+
+	return ret, nil
+}
+
+// parseReportTime parses time in credential report. Values like N/A, no_information are returned as nil
+func parseReportTime(value string) *time.Time {
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil
+	}
+	return &t
+}
+
+// parseReportBool parses boolean in credential report. Values like not_supported are returned as nil
+func parseReportBool(value string) *bool {
+	switch value {
+	case "true":
+		return aws.Bool(true)
+	case "false":
+		return aws.Bool(false)
+	}
+	return nil
+}
+
+// parseReportString returns nil for N/A in credential report
+func parseReportString(value string) *string {
+	if len(value) == 0 || value == "N/A" {
+		return nil
+	}
+	return aws.String(value)
+}
+
+// daysSince returns days from the time to now
+func daysSince(t *time.Time) *int {
+	if t == nil {
+		return nil
+	}
+	return aws.Int(int(time.Since(*t).Hours() / 24))
+}
+
+// latestTime returns the latest time among times
+func latestTime(times ...*time.Time) *time.Time {
+	var latest *time.Time
+	for _, t := range times {
+		if t != nil && (latest == nil || t.After(*latest)) {
+			latest = t
+		}
+	}
+	return latest
+}
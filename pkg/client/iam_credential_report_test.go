@@ -0,0 +1,65 @@
+/*
+Copyright 2020 The redhawk Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"testing"
+
+	"github.com/DevopsArtFactory/redhawk/pkg/constants"
+)
+
+func TestParseCredentialReport(t *testing.T) {
+	content := `user,arn,user_creation_time,password_enabled,password_last_used,password_last_changed,password_next_rotation,mfa_active,access_key_1_active,access_key_1_last_rotated,access_key_1_last_used_date,access_key_1_last_used_region,access_key_1_last_used_service,access_key_2_active,access_key_2_last_rotated,access_key_2_last_used_date,access_key_2_last_used_region,access_key_2_last_used_service,cert_1_active,cert_1_last_rotated,cert_2_active,cert_2_last_rotated
+<root_account>,arn:aws:iam::123456789012:root,2019-01-01T00:00:00+00:00,not_supported,2021-01-01T00:00:00+00:00,not_supported,not_supported,true,false,N/A,N/A,N/A,N/A,false,N/A,N/A,N/A,N/A,false,N/A,false,N/A
+alice,arn:aws:iam::123456789012:user/alice,2020-01-01T00:00:00+00:00,true,no_information,2020-01-01T00:00:00+00:00,N/A,false,true,2020-02-01T00:00:00+00:00,2021-03-01T00:00:00+00:00,us-east-1,s3,true,2021-02-01T00:00:00+00:00,N/A,N/A,N/A,false,N/A,false,N/A
+`
+
+	report, err := parseCredentialReport([]byte(content))
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	root, ok := report[constants.RootAccountUser]
+	if !ok {
+		t.Fatal("root account is not parsed")
+	}
+
+	if root.PasswordEnabled != nil {
+		t.Errorf("password of root should be unknown: %t", *root.PasswordEnabled)
+	}
+
+	if root.MFAActive == nil || !*root.MFAActive {
+		t.Error("mfa of root should be active")
+	}
+
+	alice := report["alice"]
+	if alice.PasswordLastUsed != nil {
+		t.Errorf("password last used should be empty: %s", alice.PasswordLastUsed)
+	}
+
+	if alice.AccessKeys[0].LastUsedService == nil || *alice.AccessKeys[0].LastUsedService != "s3" {
+		t.Error("last used service of access key 1 should be s3")
+	}
+
+	if alice.AccessKeys[1].LastUsed != nil || alice.AccessKeys[1].LastUsedService != nil {
+		t.Error("access key 2 should not be used")
+	}
+
+	if latest := latestTime(alice.AccessKeys[0].LastUsed, alice.AccessKeys[1].LastUsed); latest == nil || latest.Year() != 2021 {
+		t.Errorf("latest usage of access keys is wrong: %v", latest)
+	}
+}
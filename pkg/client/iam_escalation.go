@@ -0,0 +1,582 @@
+/*
+Copyright 2020 The redhawk Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	"github.com/aws/aws-sdk-go-v2/service/iam/types"
+	"github.com/sirupsen/logrus"
+
+	"github.com/DevopsArtFactory/redhawk/pkg/constants"
+	"github.com/DevopsArtFactory/redhawk/pkg/resource"
+)
+
+// escalationPrimitive is a set of actions which allows a principal to gain admin-equivalent access
+type escalationPrimitive struct {
+	Name    string
+	Actions []string
+}
+
+// adminActions are actions which NotAction on all resources should allow to be admin-equivalent.
+// Policies like PowerUserAccess exclude IAM actions with NotAction
+var adminActions = []string{"iam:PassRole", "iam:CreatePolicyVersion"}
+
+// escalationPrimitives are well-known IAM privilege escalation methods
+var escalationPrimitives = []escalationPrimitive{
+	{Name: "CreatePolicyVersion", Actions: []string{"iam:CreatePolicyVersion"}},
+	{Name: "SetDefaultPolicyVersion", Actions: []string{"iam:SetDefaultPolicyVersion"}},
+	{Name: "CreateAccessKey", Actions: []string{"iam:CreateAccessKey"}},
+	{Name: "CreateLoginProfile", Actions: []string{"iam:CreateLoginProfile"}},
+	{Name: "UpdateLoginProfile", Actions: []string{"iam:UpdateLoginProfile"}},
+	{Name: "AttachUserPolicy", Actions: []string{"iam:AttachUserPolicy"}},
+	{Name: "AttachGroupPolicy", Actions: []string{"iam:AttachGroupPolicy"}},
+	{Name: "AttachRolePolicy", Actions: []string{"iam:AttachRolePolicy", "sts:AssumeRole"}},
+	{Name: "PutUserPolicy", Actions: []string{"iam:PutUserPolicy"}},
+	{Name: "PutGroupPolicy", Actions: []string{"iam:PutGroupPolicy"}},
+	{Name: "PutRolePolicy", Actions: []string{"iam:PutRolePolicy", "sts:AssumeRole"}},
+	{Name: "AddUserToGroup", Actions: []string{"iam:AddUserToGroup"}},
+	{Name: "UpdateAssumeRolePolicy", Actions: []string{"iam:UpdateAssumeRolePolicy", "sts:AssumeRole"}},
+	{Name: "PassRole+EC2", Actions: []string{"iam:PassRole", "ec2:RunInstances"}},
+	{Name: "PassRole+Lambda", Actions: []string{"iam:PassRole", "lambda:CreateFunction", "lambda:InvokeFunction"}},
+	{Name: "PassRole+LambdaEventSource", Actions: []string{"iam:PassRole", "lambda:CreateFunction", "lambda:CreateEventSourceMapping"}},
+	{Name: "UpdateFunctionCode", Actions: []string{"lambda:UpdateFunctionCode"}},
+	{Name: "PassRole+Glue", Actions: []string{"iam:PassRole", "glue:CreateDevEndpoint"}},
+	{Name: "UpdateGlueDevEndpoint", Actions: []string{"glue:UpdateDevEndpoint"}},
+	{Name: "PassRole+CloudFormation", Actions: []string{"iam:PassRole", "cloudformation:CreateStack"}},
+	{Name: "PassRole+DataPipeline", Actions: []string{"iam:PassRole", "datapipeline:CreatePipeline", "datapipeline:PutPipelineDefinition"}},
+}
+
+type IAMEscalationClient struct {
+	Resource string
+	IAM      *IAMClient
+	Alias    *string
+}
+
+// escalationPrincipal is a principal with effective permissions
+type escalationPrincipal struct {
+	Type        string
+	Name        string
+	Arn         string
+	Permissions permissionSet
+	Trust       *PolicyDocument
+}
+
+// permissionSet is the effective permission built from identity policies.
+// Conditions, permissions boundaries and service control policies are not evaluated
+type permissionSet struct {
+	Allow []StatementEntry
+	Deny  []StatementEntry
+}
+
+// GetResourceName returns resource name of client
+func (e *IAMEscalationClient) GetResourceName() string {
+	return e.Resource
+}
+
+// NewIAMEscalationClient creates a IAMEscalationClient
+func NewIAMEscalationClient(cfg aws.Config, _ Helper) (Client, error) {
+	return &IAMEscalationClient{
+		Resource: constants.IAMEscalationResourceName,
+		IAM: &IAMClient{
+			Resource: constants.IAMResourceName,
+			Client:   GetIAMClientFn(cfg),
+			Config:   cfg,
+		},
+	}, nil
+}
+
+// Scan scans all data
+func (e *IAMEscalationClient) Scan() ([]resource.Resource, error) {
+	var result []resource.Resource
+
+	logrus.Debug("Start analyzing IAM privilege escalation in the account")
+	details, err := e.IAM.GetAuthorizationDetails()
+	if err != nil {
+		return nil, err
+	}
+
+	principals, err := e.BuildPrincipals(details)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, principal := range principals {
+		paths := findEscalationPaths(principal, principals)
+		if len(paths) == 0 {
+			continue
+		}
+
+		tmp := resource.IAMEscalationResource{
+			ResourceType: aws.String(constants.IAMEscalationResourceName),
+		}
+
+		tmp.PrincipalType = aws.String(principal.Type)
+		tmp.PrincipalName = aws.String(principal.Name)
+		tmp.AdminEquivalent = aws.Bool(principal.Permissions.isAdmin())
+		tmp.Primitives = aws.String(strings.Join(principal.Permissions.primitives(), constants.DefaultDelimiter))
+		tmp.PathCount = aws.Int(len(paths))
+		tmp.Paths = aws.String(strings.Join(paths, constants.DefaultDelimiter))
+
+		result = append(result, tmp)
+	}
+
+	logrus.Debugf("total valid IAM escalation data count: %d", len(result))
+
+	return result, nil
+}
+
+// BuildPrincipals builds effective permissions of users, groups and roles
+func (e *IAMEscalationClient) BuildPrincipals(details *AuthorizationDetails) ([]*escalationPrincipal, error) {
+	documents := map[string]*PolicyDocument{}
+	getManaged := func(arn string) (*PolicyDocument, error) {
+		if pd, ok := documents[arn]; ok {
+			return pd, nil
+		}
+
+		var document string
+		if policy, ok := details.Policies[arn]; ok {
+			for _, version := range policy.PolicyVersionList {
+				if version.IsDefaultVersion && version.Document != nil {
+					document = *version.Document
+				}
+			}
+		} else {
+			// AWS managed policies are not in authorization details
+			d, err := e.IAM.GetPolicyDocument(arn)
+			if err != nil {
+				return nil, err
+			}
+			document = d
+		}
+
+		pd, err := ParsePolicyDocument(document)
+		if err != nil {
+			return nil, fmt.Errorf("policy parsing error: %s", arn)
+		}
+		documents[arn] = pd
+
+		return pd, nil
+	}
+
+	add := func(ps *permissionSet, attached []types.AttachedPolicy, inline []types.PolicyDetail) error {
+		for _, policy := range attached {
+			pd, err := getManaged(*policy.PolicyArn)
+			if err != nil {
+				return err
+			}
+			ps.add(pd)
+		}
+
+		for _, policy := range inline {
+			pd, err := ParsePolicyDocument(aws.ToString(policy.PolicyDocument))
+			if err != nil {
+				return fmt.Errorf("policy parsing error: %s", *policy.PolicyName)
+			}
+			ps.add(pd)
+		}
+		return nil
+	}
+
+	groups := map[string]permissionSet{}
+	var principals []*escalationPrincipal
+
+	for _, name := range sortedKeys(details.Groups) {
+		group := details.Groups[name]
+		var ps permissionSet
+		if err := add(&ps, group.AttachedManagedPolicies, group.GroupPolicyList); err != nil {
+			return nil, err
+		}
+		groups[name] = ps
+
+		principals = append(principals, &escalationPrincipal{
+			Type:        "group",
+			Name:        name,
+			Arn:         aws.ToString(group.Arn),
+			Permissions: ps,
+		})
+	}
+
+	for _, name := range sortedKeys(details.Users) {
+		user := details.Users[name]
+		var ps permissionSet
+		if err := add(&ps, user.AttachedManagedPolicies, user.UserPolicyList); err != nil {
+			return nil, err
+		}
+
+		for _, group := range user.GroupList {
+			ps.Allow = append(ps.Allow, groups[group].Allow...)
+			ps.Deny = append(ps.Deny, groups[group].Deny...)
+		}
+
+		principals = append(principals, &escalationPrincipal{
+			Type:        "user",
+			Name:        name,
+			Arn:         aws.ToString(user.Arn),
+			Permissions: ps,
+		})
+	}
+
+	for _, name := range sortedKeys(details.Roles) {
+		role := details.Roles[name]
+		var ps permissionSet
+		if err := add(&ps, role.AttachedManagedPolicies, role.RolePolicyList); err != nil {
+			return nil, err
+		}
+
+		principal := escalationPrincipal{
+			Type:        "role",
+			Name:        name,
+			Arn:         aws.ToString(role.Arn),
+			Permissions: ps,
+		}
+
+		if role.AssumeRolePolicyDocument != nil {
+			trust, err := ParsePolicyDocument(*role.AssumeRolePolicyDocument)
+			if err != nil {
+				return nil, fmt.Errorf("policy parsing error: %s", name)
+			}
+			principal.Trust = trust
+		}
+
+		principals = append(principals, &principal)
+	}
+
+	return principals, nil
+}
+
+// GetPolicyDocument returns default version document of managed policy
+func (i *IAMClient) GetPolicyDocument(arn string) (string, error) {
+	policy, err := i.Client.GetPolicy(context.TODO(), &iam.GetPolicyInput{
+		PolicyArn: aws.String(arn),
+	})
+	if err != nil {
+		return constants.EmptyString, err
+	}
+
+	version, err := i.Client.GetPolicyVersion(context.TODO(), &iam.GetPolicyVersionInput{
+		PolicyArn: aws.String(arn),
+		VersionId: policy.Policy.DefaultVersionId,
+	})
+	if err != nil {
+		return constants.EmptyString, err
+	}
+
+	return aws.ToString(version.PolicyVersion.Document), nil
+}
+
+// SetAlias sets alias
+func (e *IAMEscalationClient) SetAlias(alias *string) {
+	e.Alias = alias
+}
+
+// findEscalationPaths finds paths from the principal to admin-equivalent access.
+// Roles which the principal can assume are followed in breadth-first order
+func findEscalationPaths(start *escalationPrincipal, principals []*escalationPrincipal) []string {
+	type step struct {
+		principal *escalationPrincipal
+		path      []string
+	}
+
+	var paths []string
+	visited := map[string]bool{start.Arn: true}
+	queue := []step{{principal: start, path: []string{start.Type + "/" + start.Name}}}
+
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		route := strings.Join(current.path, " -> ")
+		if current.principal.Permissions.isAdmin() {
+			paths = append(paths, route+" (admin)")
+			continue
+		}
+
+		for _, primitive := range current.principal.Permissions.primitives() {
+			paths = append(paths, route+" -> "+primitive)
+		}
+
+		// groups cannot assume roles
+		if current.principal.Type == "group" || len(current.path) > constants.MaxEscalationDepth {
+			continue
+		}
+
+		for _, role := range principals {
+			if role.Type != "role" || visited[role.Arn] || !canAssume(current.principal, role) {
+				continue
+			}
+
+			visited[role.Arn] = true
+			next := append(append([]string{}, current.path...), "role/"+role.Name)
+			queue = append(queue, step{principal: role, path: next})
+		}
+	}
+
+	return paths
+}
+
+// canAssume checks if principal can assume the role with trust policy of the role
+func canAssume(principal, role *escalationPrincipal) bool {
+	if role.Trust == nil {
+		return false
+	}
+
+	account := constants.EmptyString
+	if m := accountIDRegex.FindStringSubmatch(principal.Arn); m != nil {
+		account = m[1]
+	}
+
+	for _, statement := range role.Trust.Statement {
+		if statement.Effect != "Allow" || statement.Principal == nil {
+			continue
+		}
+
+		for _, trusted := range toStringSlice(statement.Principal.AWS) {
+			switch {
+			case trusted == principal.Arn:
+				return true
+			case trusted == "*" || trusted == account || trusted == fmt.Sprintf("arn:aws:iam::%s:root", account):
+				if principal.Permissions.allows("sts:AssumeRole", role.Arn) {
+					return true
+				}
+			}
+		}
+	}
+
+	return false
+}
+
+// add adds statements of policy document.
+// Denies with conditions are ignored, because they may not apply to the request
+func (p *permissionSet) add(pd *PolicyDocument) {
+	for _, statement := range pd.Statement {
+		if statement.Effect == "Deny" {
+			if statement.Condition != nil {
+				continue
+			}
+			p.Deny = append(p.Deny, statement)
+		} else if statement.Effect == "Allow" {
+			p.Allow = append(p.Allow, statement)
+		}
+	}
+}
+
+// allows checks if action is allowed on the resource. Empty resource means any resource,
+// and only denies on all resources or with NotResource cancel it
+func (p permissionSet) allows(action, res string) bool {
+	for _, statement := range p.Deny {
+		if !matchAction(statement, action) {
+			continue
+		}
+
+		if len(res) == 0 {
+			if isWildcardResource(statement) || statement.NotResource != nil {
+				return false
+			}
+			continue
+		}
+
+		if matchResource(statement, res) || isWildcardResource(statement) {
+			return false
+		}
+	}
+
+	for _, statement := range p.Allow {
+		if matchAction(statement, action) && matchResource(statement, res) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isAdmin checks if all actions are allowed on all resources.
+// NotAction on all resources is admin-equivalent if it does not exclude IAM actions,
+// and denies on some resources do not limit it
+func (p permissionSet) isAdmin() bool {
+	for _, statement := range p.Deny {
+		if !isWildcardResource(statement) {
+			continue
+		}
+
+		for _, action := range toStringSlice(statement.Action) {
+			if action == "*" {
+				return false
+			}
+		}
+	}
+
+	for _, statement := range p.Allow {
+		if !isWildcardResource(statement) {
+			continue
+		}
+
+		if statement.NotAction != nil {
+			if matchAllActions(statement, adminActions) {
+				return true
+			}
+			continue
+		}
+
+		for _, action := range toStringSlice(statement.Action) {
+			if action == "*" {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// primitives returns names of escalation primitives allowed
+func (p permissionSet) primitives() []string {
+	var ret []string
+	for _, primitive := range escalationPrimitives {
+		allowed := true
+		for _, action := range primitive.Actions {
+			if !p.allows(action, constants.EmptyString) {
+				allowed = false
+				break
+			}
+		}
+
+		if allowed {
+			ret = append(ret, primitive.Name)
+		}
+	}
+	return ret
+}
+
+// matchAction checks if statement applies to the action
+func matchAction(statement StatementEntry, action string) bool {
+	if statement.NotAction != nil {
+		for _, pattern := range toStringSlice(statement.NotAction) {
+			if wildcardMatch(strings.ToLower(pattern), strings.ToLower(action)) {
+				return false
+			}
+		}
+		return true
+	}
+
+	for _, pattern := range toStringSlice(statement.Action) {
+		if wildcardMatch(strings.ToLower(pattern), strings.ToLower(action)) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchAllActions checks if statement applies to all of actions
+func matchAllActions(statement StatementEntry, actions []string) bool {
+	for _, action := range actions {
+		if !matchAction(statement, action) {
+			return false
+		}
+	}
+	return true
+}
+
+// matchResource checks if statement applies to the resource. Empty resource matches any statement
+func matchResource(statement StatementEntry, res string) bool {
+	if len(res) == 0 {
+		return true
+	}
+
+	if statement.NotResource != nil {
+		for _, pattern := range toStringSlice(statement.NotResource) {
+			if wildcardMatch(pattern, res) {
+				return false
+			}
+		}
+		return true
+	}
+
+	for _, pattern := range toStringSlice(statement.Resource) {
+		if wildcardMatch(pattern, res) {
+			return true
+		}
+	}
+	return false
+}
+
+// isWildcardResource checks if statement applies to all resources
+func isWildcardResource(statement StatementEntry) bool {
+	for _, pattern := range toStringSlice(statement.Resource) {
+		if pattern == "*" {
+			return true
+		}
+	}
+	return false
+}
+
+// wildcardMatch matches string with pattern of IAM policy which has `*` and `?`
+func wildcardMatch(pattern, s string) bool {
+	p, i := 0, 0
+	star, match := -1, 0
+	for i < len(s) {
+		switch {
+		case p < len(pattern) && (pattern[p] == '?' || pattern[p] == s[i]):
+			p++
+			i++
+		case p < len(pattern) && pattern[p] == '*':
+			star = p
+			match = i
+			p++
+		case star != -1:
+			p = star + 1
+			match++
+			i = match
+		default:
+			return false
+		}
+	}
+
+	for p < len(pattern) && pattern[p] == '*' {
+		p++
+	}
+	return p == len(pattern)
+}
+
+// sortedKeys returns sorted keys of map for stable output
+func sortedKeys(m interface{}) []string {
+	var keys []string
+	switch t := m.(type) {
+	case map[string]types.UserDetail:
+		for k := range t {
+			keys = append(keys, k)
+		}
+	case map[string]types.GroupDetail:
+		for k := range t {
+			keys = append(keys, k)
+		}
+	case map[string]types.RoleDetail:
+		for k := range t {
+			keys = append(keys, k)
+		}
+	case map[string]types.ManagedPolicyDetail:
+		for k := range t {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,204 @@
+/*
+Copyright 2020 The redhawk Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFindEscalationPaths(t *testing.T) {
+	parse := func(document string) *PolicyDocument {
+		pd, err := ParsePolicyDocument(document)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		return pd
+	}
+
+	permissions := func(document string) permissionSet {
+		var ps permissionSet
+		ps.add(parse(document))
+		return ps
+	}
+
+	alice := &escalationPrincipal{
+		Type:        "user",
+		Name:        "alice",
+		Arn:         "arn:aws:iam::111111111111:user/alice",
+		Permissions: permissions(`{"Statement":[{"Effect":"Allow","Action":"sts:AssumeRole","Resource":"arn:aws:iam::111111111111:role/deploy-*"}]}`),
+	}
+
+	bob := &escalationPrincipal{
+		Type:        "user",
+		Name:        "bob",
+		Arn:         "arn:aws:iam::111111111111:user/bob",
+		Permissions: permissions(`{"Statement":[{"Effect":"Allow","Action":["iam:Get*","iam:List*"],"Resource":"*"}]}`),
+	}
+
+	deploy := &escalationPrincipal{
+		Type:        "role",
+		Name:        "deploy-lambda",
+		Arn:         "arn:aws:iam::111111111111:role/deploy-lambda",
+		Permissions: permissions(`{"Statement":[{"Effect":"Allow","Action":["iam:PassRole","lambda:*"],"Resource":"*"}]}`),
+		Trust:       parse(`{"Statement":[{"Effect":"Allow","Principal":{"AWS":"arn:aws:iam::111111111111:root"},"Action":"sts:AssumeRole"}]}`),
+	}
+
+	admin := &escalationPrincipal{
+		Type:        "role",
+		Name:        "admin",
+		Arn:         "arn:aws:iam::111111111111:role/admin",
+		Permissions: permissions(`{"Statement":[{"Effect":"Allow","Action":"*","Resource":"*"}]}`),
+		Trust:       parse(`{"Statement":[{"Effect":"Allow","Principal":{"AWS":"arn:aws:iam::111111111111:role/deploy-lambda"},"Action":"sts:AssumeRole"}]}`),
+	}
+
+	principals := []*escalationPrincipal{alice, bob, deploy, admin}
+
+	paths := findEscalationPaths(alice, principals)
+	expected := []string{
+		"user/alice -> role/deploy-lambda -> PassRole+Lambda",
+		"user/alice -> role/deploy-lambda -> PassRole+LambdaEventSource",
+		"user/alice -> role/deploy-lambda -> UpdateFunctionCode",
+		"user/alice -> role/deploy-lambda -> role/admin (admin)",
+	}
+	if strings.Join(paths, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("unexpected paths of alice:\n%s", strings.Join(paths, "\n"))
+	}
+
+	if paths := findEscalationPaths(bob, principals); len(paths) != 0 {
+		t.Errorf("bob should not have escalation path: %s", strings.Join(paths, ","))
+	}
+
+	// a deny on one user does not cancel the allow on the other users
+	carol := &escalationPrincipal{
+		Type: "user",
+		Name: "carol",
+		Arn:  "arn:aws:iam::111111111111:user/carol",
+		Permissions: permissions(`{"Statement":[
+			{"Effect":"Allow","Action":"iam:CreateAccessKey","Resource":"*"},
+			{"Effect":"Deny","Action":"iam:CreateAccessKey","Resource":"arn:aws:iam::111111111111:user/breakglass"}]}`),
+	}
+	if paths := findEscalationPaths(carol, principals); strings.Join(paths, ",") != "user/carol -> CreateAccessKey" {
+		t.Errorf("unexpected paths of carol: %s", strings.Join(paths, ","))
+	}
+
+	dave := &escalationPrincipal{
+		Type: "user",
+		Name: "dave",
+		Arn:  "arn:aws:iam::111111111111:user/dave",
+		Permissions: permissions(`{"Statement":[
+			{"Effect":"Allow","Action":"iam:CreateAccessKey","Resource":"*"},
+			{"Effect":"Deny","Action":"iam:*","Resource":"*"}]}`),
+	}
+	if paths := findEscalationPaths(dave, principals); len(paths) != 0 {
+		t.Errorf("dave should not have escalation path: %s", strings.Join(paths, ","))
+	}
+
+	// a deny which forces MFA does not hide escalation paths
+	erin := &escalationPrincipal{
+		Type: "user",
+		Name: "erin",
+		Arn:  "arn:aws:iam::111111111111:user/erin",
+		Permissions: permissions(`{"Statement":[
+			{"Effect":"Allow","Action":"iam:CreateAccessKey","Resource":"*"},
+			{"Effect":"Deny","NotAction":["iam:ListMFADevices","sts:GetSessionToken"],"Resource":"*",
+				"Condition":{"BoolIfExists":{"aws:MultiFactorAuthPresent":"false"}}}]}`),
+	}
+	if paths := findEscalationPaths(erin, principals); strings.Join(paths, ",") != "user/erin -> CreateAccessKey" {
+		t.Errorf("unexpected paths of erin: %s", strings.Join(paths, ","))
+	}
+}
+
+func TestIsAdmin(t *testing.T) {
+	tests := []struct {
+		description string
+		document    string
+		admin       bool
+	}{
+		{
+			description: "all actions on all resources",
+			document:    `{"Statement":[{"Effect":"Allow","Action":"*","Resource":"*"}]}`,
+			admin:       true,
+		},
+		{
+			description: "deny on a bucket",
+			document: `{"Statement":[{"Effect":"Allow","Action":"*","Resource":"*"},
+				{"Effect":"Deny","Action":"*","Resource":"arn:aws:s3:::audit-logs"}]}`,
+			admin: true,
+		},
+		{
+			description: "deny on all resources",
+			document: `{"Statement":[{"Effect":"Allow","Action":"*","Resource":"*"},
+				{"Effect":"Deny","Action":"*","Resource":"*"}]}`,
+			admin: false,
+		},
+		{
+			description: "not action excluding no IAM action on all resources",
+			document:    `{"Statement":[{"Effect":"Allow","NotAction":"organizations:LeaveOrganization","Resource":"*"}]}`,
+			admin:       true,
+		},
+		{
+			description: "PowerUserAccess",
+			document: `{"Statement":[{"Effect":"Allow","NotAction":["iam:*","organizations:*","account:*"],"Resource":"*"},
+				{"Effect":"Allow","Action":["iam:CreateServiceLinkedRole","iam:DeleteServiceLinkedRole","iam:ListRoles","organizations:DescribeOrganization","account:ListRegions"],"Resource":"*"}]}`,
+			admin: false,
+		},
+		{
+			description: "not action excluding passing roles on all resources",
+			document:    `{"Statement":[{"Effect":"Allow","NotAction":"iam:PassRole","Resource":"*"}]}`,
+			admin:       false,
+		},
+		{
+			description: "not action on a bucket",
+			document:    `{"Statement":[{"Effect":"Allow","NotAction":"s3:DeleteBucket","Resource":"arn:aws:s3:::audit-logs"}]}`,
+			admin:       false,
+		},
+	}
+
+	for _, test := range tests {
+		pd, err := ParsePolicyDocument(test.document)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+
+		var ps permissionSet
+		ps.add(pd)
+		if ps.isAdmin() != test.admin {
+			t.Errorf("%s: admin should be %t", test.description, test.admin)
+		}
+	}
+}
+
+func TestWildcardMatch(t *testing.T) {
+	tests := []struct {
+		pattern string
+		s       string
+		match   bool
+	}{
+		{pattern: "*", s: "iam:passrole", match: true},
+		{pattern: "iam:*", s: "iam:passrole", match: true},
+		{pattern: "iam:pass?ole", s: "iam:passrole", match: true},
+		{pattern: "iam:create*", s: "iam:passrole", match: false},
+		{pattern: "arn:aws:iam::*:role/deploy-*", s: "arn:aws:iam::111111111111:role/deploy-app", match: true},
+	}
+
+	for _, test := range tests {
+		if wildcardMatch(test.pattern, test.s) != test.match {
+			t.Errorf("%s with %s should be %t", test.pattern, test.s, test.match)
+		}
+	}
+}
@@ -0,0 +1,307 @@
+/*
+Copyright 2020 The redhawk Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	"github.com/aws/aws-sdk-go-v2/service/iam/types"
+	"github.com/sirupsen/logrus"
+
+	"github.com/DevopsArtFactory/redhawk/pkg/constants"
+	"github.com/DevopsArtFactory/redhawk/pkg/resource"
+	"github.com/DevopsArtFactory/redhawk/pkg/tools"
+)
+
+// AuthorizationDetails is a snapshot of users, groups, roles and customer managed policies in the account
+type AuthorizationDetails struct {
+	Users    map[string]types.UserDetail
+	Groups   map[string]types.GroupDetail
+	Roles    map[string]types.RoleDetail
+	Policies map[string]types.ManagedPolicyDetail
+}
+
+// UnmarshalJSON parses policy document with a single statement or a list of statements
+func (p *PolicyDocument) UnmarshalJSON(b []byte) error {
+	var raw struct {
+		Version   string
+		Statement json.RawMessage
+	}
+
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+
+	p.Version = raw.Version
+	p.Statement = nil
+
+	statement := bytes.TrimSpace(raw.Statement)
+	if len(statement) == 0 {
+		return nil
+	}
+
+	if statement[0] == '{' {
+		var entry StatementEntry
+		if err := json.Unmarshal(statement, &entry); err != nil {
+			return err
+		}
+		p.Statement = []StatementEntry{entry}
+		return nil
+	}
+
+	return json.Unmarshal(statement, &p.Statement)
+}
+
+// ParsePolicyDocument parses JSON policy document which may be URL-encoded
+func ParsePolicyDocument(document string) (*PolicyDocument, error) {
+	var err error
+	decoded := document
+	if strings.HasPrefix(document, "%") {
+		decoded, err = tools.DecodeURLEncodedString(document)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var pd PolicyDocument
+	if err := json.Unmarshal([]byte(decoded), &pd); err != nil {
+		return nil, err
+	}
+
+	return &pd, nil
+}
+
+// HasWildcard checks if allowed statements of policy have `*` in actions or resources.
+// Service wide actions like `s3:*` and NotAction, NotResource are also regarded as wildcard
+func (p PolicyDocument) HasWildcard() (bool, bool) {
+	var action, res bool
+	for _, statement := range p.Statement {
+		if statement.Effect != "Allow" {
+			continue
+		}
+
+		if statement.NotAction != nil {
+			action = true
+		}
+
+		for _, a := range toStringSlice(statement.Action) {
+			if a == "*" || strings.HasSuffix(a, ":*") {
+				action = true
+			}
+		}
+
+		if statement.NotResource != nil {
+			res = true
+		}
+
+		for _, r := range toStringSlice(statement.Resource) {
+			if r == "*" {
+				res = true
+			}
+		}
+	}
+
+	return action, res
+}
+
+// GetAuthorizationDetails returns users, groups, roles and customer managed policies with their policy documents
+func (i *IAMClient) GetAuthorizationDetails() (*AuthorizationDetails, error) {
+	details := AuthorizationDetails{
+		Users:    map[string]types.UserDetail{},
+		Groups:   map[string]types.GroupDetail{},
+		Roles:    map[string]types.RoleDetail{},
+		Policies: map[string]types.ManagedPolicyDetail{},
+	}
+
+	var marker *string
+	for {
+		result, err := i.Client.GetAccountAuthorizationDetails(context.TODO(), &iam.GetAccountAuthorizationDetailsInput{
+			Filter: []types.EntityType{
+				types.EntityTypeUser,
+				types.EntityTypeGroup,
+				types.EntityTypeRole,
+				types.EntityTypeLocalManagedPolicy,
+			},
+			Marker: marker,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, user := range result.UserDetailList {
+			details.Users[*user.UserName] = user
+		}
+
+		for _, group := range result.GroupDetailList {
+			details.Groups[*group.GroupName] = group
+		}
+
+		for _, role := range result.RoleDetailList {
+			details.Roles[*role.RoleName] = role
+		}
+
+		for _, policy := range result.Policies {
+			details.Policies[*policy.Arn] = policy
+		}
+
+		if !result.IsTruncated {
+			break
+		}
+		marker = result.Marker
+	}
+
+	return &details, nil
+}
+
+// ScanPolicy scans customer managed policies and inline policies of users, groups and roles
+func (i *IAMClient) ScanPolicy(details *AuthorizationDetails) []resource.Resource {
+	var result []resource.Resource
+
+	logrus.Debug("Start scanning all IAM policies in the account")
+
+	// entities of customer managed policies
+	attached := map[string][]string{}
+	for _, name := range sortedKeys(details.Users) {
+		for _, policy := range details.Users[name].AttachedManagedPolicies {
+			attached[*policy.PolicyArn] = append(attached[*policy.PolicyArn], "user/"+name)
+		}
+	}
+
+	for _, name := range sortedKeys(details.Groups) {
+		for _, policy := range details.Groups[name].AttachedManagedPolicies {
+			attached[*policy.PolicyArn] = append(attached[*policy.PolicyArn], "group/"+name)
+		}
+	}
+
+	for _, name := range sortedKeys(details.Roles) {
+		for _, policy := range details.Roles[name].AttachedManagedPolicies {
+			attached[*policy.PolicyArn] = append(attached[*policy.PolicyArn], "role/"+name)
+		}
+	}
+
+	for _, arn := range sortedKeys(details.Policies) {
+		policy := details.Policies[arn]
+		tmp := resource.IAMPolicyResource{
+			ResourceType: aws.String(constants.IAMPolicyResourceName),
+		}
+
+		tmp.PolicyName = policy.PolicyName
+		tmp.PolicyArn = policy.Arn
+		tmp.PolicyType = aws.String(constants.IAMPolicyTypeManaged)
+		tmp.DefaultVersion = policy.DefaultVersionId
+		tmp.Created = policy.CreateDate
+		tmp.Updated = policy.UpdateDate
+
+		if policy.AttachmentCount != nil {
+			tmp.AttachmentCount = aws.Int(int(*policy.AttachmentCount))
+		}
+
+		entities := attached[arn]
+		sort.Strings(entities)
+		tmp.AttachedEntities = aws.String(strings.Join(entities, constants.DefaultDelimiter))
+
+		for _, version := range policy.PolicyVersionList {
+			if !version.IsDefaultVersion || version.Document == nil {
+				continue
+			}
+
+			pd, err := ParsePolicyDocument(*version.Document)
+			if err != nil {
+				logrus.Errorf("policy parsing error: %s", *policy.PolicyName)
+				break
+			}
+
+			action, res := pd.HasWildcard()
+			tmp.WildcardAction = aws.Bool(action)
+			tmp.WildcardResource = aws.Bool(res)
+		}
+
+		result = append(result, tmp)
+	}
+
+	for _, name := range sortedKeys(details.Users) {
+		result = append(result, inlinePolicyResources("user/"+name, details.Users[name].UserPolicyList)...)
+	}
+
+	for _, name := range sortedKeys(details.Groups) {
+		result = append(result, inlinePolicyResources("group/"+name, details.Groups[name].GroupPolicyList)...)
+	}
+
+	for _, name := range sortedKeys(details.Roles) {
+		result = append(result, inlinePolicyResources("role/"+name, details.Roles[name].RolePolicyList)...)
+	}
+
+	logrus.Debugf("total valid IAM policy data count: %d", len(result))
+
+	return result
+}
+
+// inlinePolicyResources creates policy resources from inline policies of the entity.
+// Inline policies have no ARN, so the entity and the name identify them
+func inlinePolicyResources(entity string, policies []types.PolicyDetail) []resource.Resource {
+	var result []resource.Resource
+	for _, policy := range policies {
+		tmp := resource.IAMPolicyResource{
+			ResourceType: aws.String(constants.IAMPolicyResourceName),
+		}
+
+		tmp.PolicyName = policy.PolicyName
+		tmp.PolicyID = aws.String(entity + "/" + aws.ToString(policy.PolicyName))
+		tmp.PolicyType = aws.String(constants.IAMPolicyTypeInline)
+		tmp.AttachmentCount = aws.Int(1)
+		tmp.AttachedEntities = aws.String(entity)
+
+		if policy.PolicyDocument != nil {
+			pd, err := ParsePolicyDocument(*policy.PolicyDocument)
+			if err != nil {
+				logrus.Errorf("policy parsing error: %s", *policy.PolicyName)
+			} else {
+				action, res := pd.HasWildcard()
+				tmp.WildcardAction = aws.Bool(action)
+				tmp.WildcardResource = aws.Bool(res)
+			}
+		}
+
+		result = append(result, tmp)
+	}
+
+	return result
+}
+
+// toStringSlice converts a string or a list of strings in policy document to slice
+func toStringSlice(v interface{}) []string {
+	switch t := v.(type) {
+	case string:
+		return []string{t}
+	case []interface{}:
+		var ret []string
+		for _, s := range t {
+			if str, ok := s.(string); ok {
+				ret = append(ret, str)
+			}
+		}
+		return ret
+	}
+
+	return nil
+}
@@ -0,0 +1,72 @@
+/*
+Copyright 2020 The redhawk Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/iam/types"
+
+	"github.com/DevopsArtFactory/redhawk/pkg/resource"
+)
+
+func TestScanPolicy(t *testing.T) {
+	inline := []types.PolicyDetail{
+		{PolicyName: aws.String("default"), PolicyDocument: aws.String(`{"Statement":{"Effect":"Allow","Action":"s3:GetObject","Resource":"*"}}`)},
+	}
+
+	details := &AuthorizationDetails{
+		Users:  map[string]types.UserDetail{},
+		Groups: map[string]types.GroupDetail{},
+		Roles: map[string]types.RoleDetail{
+			"worker": {RoleName: aws.String("worker"), RolePolicyList: inline},
+			"batch":  {RoleName: aws.String("batch"), RolePolicyList: inline},
+		},
+		Policies: map[string]types.ManagedPolicyDetail{
+			"arn:aws:iam::111111111111:policy/b": {PolicyName: aws.String("b"), Arn: aws.String("arn:aws:iam::111111111111:policy/b")},
+			"arn:aws:iam::111111111111:policy/a": {PolicyName: aws.String("a"), Arn: aws.String("arn:aws:iam::111111111111:policy/a")},
+		},
+	}
+
+	expected := []string{
+		"arn:aws:iam::111111111111:policy/a",
+		"arn:aws:iam::111111111111:policy/b",
+		"role/batch/default",
+		"role/worker/default",
+	}
+
+	// output should not depend on the order of maps
+	for i := 0; i < 5; i++ {
+		result := (&IAMClient{}).ScanPolicy(details)
+		if len(result) != len(expected) {
+			t.Fatalf("expected %d policies, got %d", len(expected), len(result))
+		}
+
+		for j, r := range result {
+			policy := r.(resource.IAMPolicyResource)
+			id := aws.ToString(policy.PolicyArn)
+			if policy.PolicyID != nil {
+				id = *policy.PolicyID
+			}
+
+			if id != expected[j] {
+				t.Errorf("policy %d should be %s, got %s", j, expected[j], id)
+			}
+		}
+	}
+}
@@ -0,0 +1,222 @@
+/*
+Copyright 2020 The redhawk Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"encoding/json"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/DevopsArtFactory/redhawk/pkg/constants"
+	"github.com/DevopsArtFactory/redhawk/pkg/tools"
+)
+
+// accountIDRegex finds account ID in principal of trust policy
+var accountIDRegex = regexp.MustCompile(`^(?:arn:[^:]+:(?:iam|sts)::)?(\d{12})(?::|$)`)
+
+// trustRank is the order of risk in trust classifications
+var trustRank = map[string]int{
+	constants.TrustSameAccount:            0,
+	constants.TrustKnownOrgAccount:        1,
+	constants.TrustUnknownExternalAccount: 2,
+	constants.TrustPublic:                 3,
+}
+
+// TrustContext has accounts for classification of principals in trust policy
+type TrustContext struct {
+	AccountID      string
+	OrganizationID string
+	OrgAccounts    map[string]bool
+}
+
+// TrustAnalysis is the result of trust policy analysis
+type TrustAnalysis struct {
+	Classification     string
+	TrustedEntities    []string
+	ExternalAccounts   []string
+	ExternalIDRequired bool
+	Conditions         []string
+}
+
+// UnmarshalJSON parses principal which is `*` or a map of principal types
+func (p *Principal) UnmarshalJSON(b []byte) error {
+	var wildcard string
+	if err := json.Unmarshal(b, &wildcard); err == nil {
+		p.AWS = wildcard
+		return nil
+	}
+
+	type principal Principal
+	var tmp principal
+	if err := json.Unmarshal(b, &tmp); err != nil {
+		return err
+	}
+
+	*p = Principal(tmp)
+	return nil
+}
+
+// GetTrustContext returns account and organization of current credentials
+func (i *IAMClient) GetTrustContext() (TrustContext, error) {
+	var tc TrustContext
+
+	sts, err := NewSTSClient(i.Config)
+	if err != nil {
+		return tc, err
+	}
+
+	accountID, err := sts.GetAccountID()
+	if err != nil {
+		return tc, err
+	}
+	tc.AccountID = *accountID
+
+	orgID, accounts, err := GetOrganizationAccountIDs(i.Config)
+	if err != nil {
+		logrus.Debugf("organization accounts cannot be used for trust policy analysis: %s", err.Error())
+		return tc, nil
+	}
+
+	tc.OrganizationID = orgID
+	tc.OrgAccounts = accounts
+
+	return tc, nil
+}
+
+// AnalyzeTrustPolicy classifies principals allowed to assume the role.
+// Classification is the riskiest one among statements: public, unknown-external-account, known-org-account, same-account
+func AnalyzeTrustPolicy(pd *PolicyDocument, tc TrustContext) TrustAnalysis {
+	ret := TrustAnalysis{
+		Classification: constants.TrustSameAccount,
+	}
+
+	external := map[string]bool{}
+	externalStatements := 0
+	externalIDStatements := 0
+
+	classify := func(classification string) {
+		if trustRank[classification] > trustRank[ret.Classification] {
+			ret.Classification = classification
+		}
+	}
+
+	classifyAccount := func(account string) string {
+		switch {
+		case account == tc.AccountID:
+			return constants.TrustSameAccount
+		case tc.OrgAccounts[account]:
+			external[account] = true
+			return constants.TrustKnownOrgAccount
+		default:
+			external[account] = true
+			return constants.TrustUnknownExternalAccount
+		}
+	}
+
+	for _, statement := range pd.Statement {
+		if statement.Effect != "Allow" || statement.Principal == nil {
+			continue
+		}
+
+		conditions := map[string][]string{}
+		for operator, values := range statement.Condition {
+			for key, value := range values {
+				ret.Conditions = append(ret.Conditions, operator+":"+key)
+				conditions[strings.ToLower(key)] = append(conditions[strings.ToLower(key)], toStringSlice(value)...)
+			}
+		}
+
+		statementClass := constants.TrustSameAccount
+		for _, principal := range toStringSlice(statement.Principal.AWS) {
+			ret.TrustedEntities = append(ret.TrustedEntities, principal)
+
+			class := constants.TrustPublic
+			if principal != "*" {
+				class = constants.TrustUnknownExternalAccount
+				if m := accountIDRegex.FindStringSubmatch(principal); m != nil {
+					class = classifyAccount(m[1])
+				}
+			} else if orgIDs, ok := conditions["aws:principalorgid"]; ok && len(tc.OrganizationID) > 0 && tools.IsStringInArray(tc.OrganizationID, orgIDs) {
+				class = constants.TrustKnownOrgAccount
+			} else if accounts := append(append([]string{}, conditions["aws:principalaccount"]...), conditions["aws:sourceaccount"]...); len(accounts) > 0 {
+				class = constants.TrustSameAccount
+				for _, account := range accounts {
+					if c := classifyAccount(account); trustRank[c] > trustRank[class] {
+						class = c
+					}
+				}
+			}
+
+			if trustRank[class] > trustRank[statementClass] {
+				statementClass = class
+			}
+		}
+
+		for _, federated := range toStringSlice(statement.Principal.Federated) {
+			ret.TrustedEntities = append(ret.TrustedEntities, federated)
+
+			if isWebIdentityProvider(federated) && !hasSubjectCondition(federated, conditions) {
+				statementClass = constants.TrustPublic
+			}
+		}
+
+		ret.TrustedEntities = append(ret.TrustedEntities, toStringSlice(statement.Principal.Service)...)
+
+		if statementClass == constants.TrustKnownOrgAccount || statementClass == constants.TrustUnknownExternalAccount {
+			externalStatements++
+			if _, ok := conditions["sts:externalid"]; ok {
+				externalIDStatements++
+			}
+		}
+
+		classify(statementClass)
+	}
+
+	ret.ExternalIDRequired = externalStatements > 0 && externalStatements == externalIDStatements
+
+	for account := range external {
+		ret.ExternalAccounts = append(ret.ExternalAccounts, account)
+	}
+	sort.Strings(ret.ExternalAccounts)
+	sort.Strings(ret.TrustedEntities)
+	sort.Strings(ret.Conditions)
+
+	return ret
+}
+
+// isWebIdentityProvider checks if federated principal is an OIDC or web identity provider, not SAML
+func isWebIdentityProvider(federated string) bool {
+	return strings.Contains(federated, ":oidc-provider/") || !strings.HasPrefix(federated, "arn:")
+}
+
+// hasSubjectCondition checks if tokens of web identity provider are restricted with subject claim
+func hasSubjectCondition(federated string, conditions map[string][]string) bool {
+	for key := range conditions {
+		if strings.HasSuffix(key, ":sub") {
+			return true
+		}
+
+		// cognito identity pool is restricted with audience
+		if federated == "cognito-identity.amazonaws.com" && key == "cognito-identity.amazonaws.com:aud" {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,127 @@
+/*
+Copyright 2020 The redhawk Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/DevopsArtFactory/redhawk/pkg/constants"
+)
+
+func TestAnalyzeTrustPolicy(t *testing.T) {
+	tc := TrustContext{
+		AccountID:      "111111111111",
+		OrganizationID: "o-abcdefghij",
+		OrgAccounts: map[string]bool{
+			"111111111111": true,
+			"222222222222": true,
+		},
+	}
+
+	tests := []struct {
+		name           string
+		document       string
+		classification string
+		externalID     bool
+	}{
+		{
+			name:           "service",
+			document:       `{"Statement":[{"Effect":"Allow","Principal":{"Service":"ec2.amazonaws.com"},"Action":"sts:AssumeRole"}]}`,
+			classification: constants.TrustSameAccount,
+		},
+		{
+			name:           "same account",
+			document:       `{"Statement":{"Effect":"Allow","Principal":{"AWS":"arn:aws:iam::111111111111:root"},"Action":"sts:AssumeRole"}}`,
+			classification: constants.TrustSameAccount,
+		},
+		{
+			name:           "organization account",
+			document:       `{"Statement":[{"Effect":"Allow","Principal":{"AWS":["222222222222"]},"Action":"sts:AssumeRole"}]}`,
+			classification: constants.TrustKnownOrgAccount,
+		},
+		{
+			name:           "external account with external id",
+			document:       `{"Statement":[{"Effect":"Allow","Principal":{"AWS":"arn:aws:iam::333333333333:role/vendor"},"Action":"sts:AssumeRole","Condition":{"StringEquals":{"sts:ExternalId":"secret"}}}]}`,
+			classification: constants.TrustUnknownExternalAccount,
+			externalID:     true,
+		},
+		{
+			name:           "wildcard principal",
+			document:       `{"Statement":[{"Effect":"Allow","Principal":"*","Action":"sts:AssumeRole"}]}`,
+			classification: constants.TrustPublic,
+		},
+		{
+			name:           "wildcard principal in organization",
+			document:       `{"Statement":[{"Effect":"Allow","Principal":{"AWS":"*"},"Action":"sts:AssumeRole","Condition":{"StringEquals":{"aws:PrincipalOrgID":"o-abcdefghij"}}}]}`,
+			classification: constants.TrustKnownOrgAccount,
+		},
+		{
+			name:           "oidc without subject",
+			document:       `{"Statement":[{"Effect":"Allow","Principal":{"Federated":"arn:aws:iam::111111111111:oidc-provider/token.actions.githubusercontent.com"},"Action":"sts:AssumeRoleWithWebIdentity","Condition":{"StringEquals":{"token.actions.githubusercontent.com:aud":"sts.amazonaws.com"}}}]}`,
+			classification: constants.TrustPublic,
+		},
+		{
+			name:           "oidc with subject",
+			document:       `{"Statement":[{"Effect":"Allow","Principal":{"Federated":"arn:aws:iam::111111111111:oidc-provider/token.actions.githubusercontent.com"},"Action":"sts:AssumeRoleWithWebIdentity","Condition":{"StringLike":{"token.actions.githubusercontent.com:sub":"repo:org/repo:*"}}}]}`,
+			classification: constants.TrustSameAccount,
+		},
+	}
+
+	for _, test := range tests {
+		pd, err := ParsePolicyDocument(test.document)
+		if err != nil {
+			t.Fatalf("%s: %s", test.name, err.Error())
+		}
+
+		trust := AnalyzeTrustPolicy(pd, tc)
+		if trust.Classification != test.classification {
+			t.Errorf("%s: expected %s, got %s", test.name, test.classification, trust.Classification)
+		}
+
+		if trust.ExternalIDRequired != test.externalID {
+			t.Errorf("%s: expected external id required %t", test.name, test.externalID)
+		}
+	}
+}
+
+func TestAnalyzeTrustPolicyOrder(t *testing.T) {
+	pd, err := ParsePolicyDocument(`{"Statement":[{"Effect":"Allow","Principal":{"AWS":["arn:aws:iam::222222222222:root","*"],"Service":"ec2.amazonaws.com"},"Action":"sts:AssumeRole",
+		"Condition":{"StringEquals":{"aws:SourceAccount":"333333333333","aws:PrincipalAccount":"222222222222","sts:ExternalId":"x"},"Bool":{"aws:SecureTransport":"true"}}}]}`)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	entities := "*|arn:aws:iam::222222222222:root|ec2.amazonaws.com"
+	conditions := "Bool:aws:SecureTransport|StringEquals:aws:PrincipalAccount|StringEquals:aws:SourceAccount|StringEquals:sts:ExternalId"
+
+	// conditions are maps, so the result should not depend on their order
+	for i := 0; i < 10; i++ {
+		trust := AnalyzeTrustPolicy(pd, TrustContext{AccountID: "111111111111"})
+		if got := strings.Join(trust.TrustedEntities, "|"); got != entities {
+			t.Errorf("unexpected trusted entities: %s", got)
+		}
+
+		if got := strings.Join(trust.Conditions, "|"); got != conditions {
+			t.Errorf("unexpected conditions: %s", got)
+		}
+
+		if got := strings.Join(trust.ExternalAccounts, "|"); got != "222222222222|333333333333" {
+			t.Errorf("unexpected external accounts: %s", got)
+		}
+	}
+}
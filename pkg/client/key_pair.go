@@ -0,0 +1,118 @@
+/*
+Copyright 2020 The redhawk Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/sirupsen/logrus"
+
+	"github.com/DevopsArtFactory/redhawk/pkg/constants"
+	"github.com/DevopsArtFactory/redhawk/pkg/resource"
+)
+
+type KeyPairClient struct {
+	Resource string
+	Client   *ec2.Client
+	Region   string
+	Alias    *string
+}
+
+// GetResourceName returns resource name of client
+func (k *KeyPairClient) GetResourceName() string {
+	return k.Resource
+}
+
+// NewKeyPairClient creates a KeyPairClient
+func NewKeyPairClient(cfg aws.Config, helper Helper) (Client, error) {
+	return &KeyPairClient{
+		Resource: constants.KeyPairResourceName,
+		Client:   GetEC2ClientFn(cfg),
+		Region:   helper.Region,
+	}, nil
+}
+
+// Scan scans all key pairs with the number of instances launched with them
+func (k *KeyPairClient) Scan() ([]resource.Resource, error) {
+	var result []resource.Resource
+
+	logrus.Debug("Start scanning all key pairs in the region")
+	keyPairs, err := k.GetKeyPairs()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(keyPairs) == 0 {
+		logrus.Debug("no key pair found")
+		return nil, nil
+	}
+
+	ec2Client := EC2Client{Client: k.Client}
+	reservations, err := ec2Client.GetEC2Instances(nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	usage := keyPairUsage(reservations)
+
+	for _, keyPair := range keyPairs {
+		result = append(result, resource.KeyPairResource{
+			ResourceType: aws.String(constants.KeyPairResourceName),
+			KeyName:      keyPair.KeyName,
+			KeyPairID:    keyPair.KeyPairId,
+			Fingerprint:  keyPair.KeyFingerprint,
+			Region:       aws.String(k.Region),
+			Instances:    aws.Int(usage[aws.ToString(keyPair.KeyName)]),
+			Tags:         ec2Tags(keyPair.Tags),
+		})
+	}
+	logrus.Debugf("total key pair count: %d", len(result))
+
+	return result, nil
+}
+
+// GetKeyPairs returns all key pairs in the region
+func (k *KeyPairClient) GetKeyPairs() ([]types.KeyPairInfo, error) {
+	result, err := k.Client.DescribeKeyPairs(context.TODO(), &ec2.DescribeKeyPairsInput{})
+	if err != nil {
+		return nil, err
+	}
+
+	return result.KeyPairs, nil
+}
+
+// keyPairUsage returns the number of instances launched with each key pair.
+// Terminated instances are kept for a while after termination, so they are not counted
+func keyPairUsage(reservations []types.Reservation) map[string]int {
+	usage := map[string]int{}
+	for _, reservation := range reservations {
+		for _, instance := range reservation.Instances {
+			if instance.KeyName != nil && instance.State != nil && instance.State.Name != types.InstanceStateNameTerminated {
+				usage[*instance.KeyName]++
+			}
+		}
+	}
+	return usage
+}
+
+// SetAlias sets alias
+func (k *KeyPairClient) SetAlias(alias *string) {
+	k.Alias = alias
+}
@@ -0,0 +1,171 @@
+/*
+Copyright 2020 The redhawk Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/kinesis"
+	"github.com/aws/aws-sdk-go-v2/service/kinesis/types"
+	"github.com/sirupsen/logrus"
+
+	"github.com/DevopsArtFactory/redhawk/pkg/constants"
+	"github.com/DevopsArtFactory/redhawk/pkg/resource"
+)
+
+type KinesisClient struct {
+	Resource string
+	Client   *kinesis.Client
+	Region   string
+	Alias    *string
+}
+
+// GetResourceName returns resource name of client
+func (k *KinesisClient) GetResourceName() string {
+	return k.Resource
+}
+
+// NewKinesisClient creates a KinesisClient
+func NewKinesisClient(cfg aws.Config, helper Helper) (Client, error) {
+	return &KinesisClient{
+		Resource: constants.KinesisResourceName,
+		Client:   GetKinesisClientFn(cfg),
+		Region:   helper.Region,
+	}, nil
+}
+
+// GetKinesisClientFn creates kinesis client
+func GetKinesisClientFn(cfg aws.Config) *kinesis.Client {
+	return kinesis.NewFromConfig(cfg)
+}
+
+// Scan scans all data
+func (k *KinesisClient) Scan() ([]resource.Resource, error) {
+	var wg sync.WaitGroup
+	var result []resource.Resource
+
+	logrus.Debug("Start scanning all kinesis streams in the region")
+	streams, err := k.GetStreamList(nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(streams) == 0 {
+		logrus.Debug("no kinesis stream found")
+		return nil, nil
+	}
+
+	input := make(chan *resource.KinesisResource)
+	output := make(chan []resource.Resource)
+	defer close(output)
+
+	go func(input chan *resource.KinesisResource, output chan []resource.Resource, wg *sync.WaitGroup) {
+		var ret []resource.Resource
+		for result := range input {
+			if result != nil {
+				ret = append(ret, *result)
+			}
+			wg.Done()
+		}
+
+		output <- ret
+	}(input, output, &wg)
+
+	f := func(stream string, ch chan *resource.KinesisResource) {
+		summary, err := k.GetStreamSummary(stream)
+		if err != nil {
+			logrus.Error(err.Error())
+			ch <- nil
+			return
+		}
+
+		tmp := resource.KinesisResource{
+			ResourceType: aws.String(constants.KinesisResourceName),
+		}
+
+		tmp.StreamName = summary.StreamName
+		tmp.Region = aws.String(k.Region)
+		tmp.StreamStatus = aws.String(string(summary.StreamStatus))
+		tmp.EncryptionType = aws.String(string(summary.EncryptionType))
+		tmp.KmsKeyID = summary.KeyId
+		tmp.Created = summary.StreamCreationTimestamp
+
+		if summary.StreamModeDetails != nil {
+			tmp.StreamMode = aws.String(string(summary.StreamModeDetails.StreamMode))
+		}
+
+		if summary.RetentionPeriodHours != nil {
+			tmp.RetentionHours = aws.Int(int(*summary.RetentionPeriodHours))
+		}
+
+		if summary.OpenShardCount != nil {
+			tmp.OpenShardCount = aws.Int(int(*summary.OpenShardCount))
+		}
+
+		logrus.Tracef("Kinesis stream is added: %s", *tmp.StreamName)
+		ch <- &tmp
+	}
+
+	logrus.Debugf("Kinesis streams found: %d", len(streams))
+	for _, stream := range streams {
+		wg.Add(1)
+		go f(stream, input)
+	}
+
+	wg.Wait()
+	close(input)
+
+	result = <-output
+	logrus.Debugf("total valid kinesis data count: %d", len(result))
+
+	return result, nil
+}
+
+// GetStreamList returns all stream names in the region
+func (k *KinesisClient) GetStreamList(original []string, exclusiveStart *string) ([]string, error) {
+	result, err := k.Client.ListStreams(context.TODO(), &kinesis.ListStreamsInput{
+		ExclusiveStartStreamName: exclusiveStart,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	original = append(original, result.StreamNames...)
+	if result.HasMoreStreams != nil && *result.HasMoreStreams && len(result.StreamNames) > 0 {
+		return k.GetStreamList(original, aws.String(result.StreamNames[len(result.StreamNames)-1]))
+	}
+	return original, nil
+}
+
+// GetStreamSummary returns summary of stream
+func (k *KinesisClient) GetStreamSummary(stream string) (*types.StreamDescriptionSummary, error) {
+	result, err := k.Client.DescribeStreamSummary(context.TODO(), &kinesis.DescribeStreamSummaryInput{
+		StreamName: aws.String(stream),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result.StreamDescriptionSummary, nil
+}
+
+// SetAlias sets alias
+func (k *KinesisClient) SetAlias(alias *string) {
+	k.Alias = alias
+}
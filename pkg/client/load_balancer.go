@@ -0,0 +1,221 @@
+/*
+Copyright 2020 The redhawk Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	elb "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancing"
+	elbTypes "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancing/types"
+	elbv2 "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2"
+	elbv2Types "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2/types"
+	"github.com/sirupsen/logrus"
+
+	"github.com/DevopsArtFactory/redhawk/pkg/constants"
+	"github.com/DevopsArtFactory/redhawk/pkg/resource"
+)
+
+// classicLoadBalancerType is the type of load balancers created with elasticloadbalancing API
+const classicLoadBalancerType = "classic"
+
+// Attributes of application and network load balancers for access logs
+const (
+	accessLogsEnabledAttribute = "access_logs.s3.enabled"
+	accessLogsBucketAttribute  = "access_logs.s3.bucket"
+)
+
+type LoadBalancerClient struct {
+	Resource string
+	Client   *elbv2.Client
+	Classic  *elb.Client
+	Region   string
+	Alias    *string
+}
+
+// GetResourceName returns resource name of client
+func (l *LoadBalancerClient) GetResourceName() string {
+	return l.Resource
+}
+
+// NewLoadBalancerClient creates a LoadBalancerClient
+func NewLoadBalancerClient(cfg aws.Config, helper Helper) (Client, error) {
+	return &LoadBalancerClient{
+		Resource: constants.LoadBalancerResourceName,
+		Client:   GetELBv2ClientFn(cfg),
+		Classic:  GetELBClientFn(cfg),
+		Region:   helper.Region,
+	}, nil
+}
+
+// GetELBv2ClientFn creates elasticloadbalancingv2 client
+func GetELBv2ClientFn(cfg aws.Config) *elbv2.Client {
+	return elbv2.NewFromConfig(cfg)
+}
+
+// GetELBClientFn creates elasticloadbalancing client for classic load balancers
+func GetELBClientFn(cfg aws.Config) *elb.Client {
+	return elb.NewFromConfig(cfg)
+}
+
+// Scan scans all data
+func (l *LoadBalancerClient) Scan() ([]resource.Resource, error) {
+	var result []resource.Resource
+
+	logrus.Debug("Start scanning all load balancers in the region")
+	loadBalancers, err := l.GetLoadBalancers(nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, lb := range loadBalancers {
+		tmp := resource.LoadBalancerResource{
+			ResourceType: aws.String(constants.LoadBalancerResourceName),
+			Name:         lb.LoadBalancerName,
+			Type:         aws.String(string(lb.Type)),
+			Scheme:       aws.String(string(lb.Scheme)),
+			DNSName:      lb.DNSName,
+			Region:       aws.String(l.Region),
+			VpcID:        lb.VpcId,
+			Created:      lb.CreatedTime,
+		}
+
+		if lb.State != nil {
+			tmp.State = aws.String(string(lb.State.Code))
+		}
+
+		// Gateway load balancers do not support access logs
+		if lb.Type != elbv2Types.LoadBalancerTypeEnumGateway {
+			enabled, bucket, err := l.GetAccessLogs(lb.LoadBalancerArn)
+			if err != nil {
+				return nil, err
+			}
+			tmp.AccessLogsEnabled = aws.Bool(enabled)
+			tmp.AccessLogsBucket = bucket
+		}
+
+		result = append(result, tmp)
+	}
+
+	classics, err := l.GetClassicLoadBalancers(nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, lb := range classics {
+		tmp := resource.LoadBalancerResource{
+			ResourceType: aws.String(constants.LoadBalancerResourceName),
+			Name:         lb.LoadBalancerName,
+			Type:         aws.String(classicLoadBalancerType),
+			Scheme:       lb.Scheme,
+			DNSName:      lb.DNSName,
+			Region:       aws.String(l.Region),
+			VpcID:        lb.VPCId,
+			Created:      lb.CreatedTime,
+		}
+
+		enabled, bucket, err := l.GetClassicAccessLogs(lb.LoadBalancerName)
+		if err != nil {
+			return nil, err
+		}
+		tmp.AccessLogsEnabled = aws.Bool(enabled)
+		tmp.AccessLogsBucket = bucket
+
+		result = append(result, tmp)
+	}
+	logrus.Debugf("total load balancer count: %d", len(result))
+
+	return result, nil
+}
+
+// GetLoadBalancers returns all application and network load balancers in the region
+func (l *LoadBalancerClient) GetLoadBalancers(original []elbv2Types.LoadBalancer, marker *string) ([]elbv2Types.LoadBalancer, error) {
+	result, err := l.Client.DescribeLoadBalancers(context.TODO(), &elbv2.DescribeLoadBalancersInput{
+		Marker: marker,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	original = append(original, result.LoadBalancers...)
+	if result.NextMarker != nil {
+		return l.GetLoadBalancers(original, result.NextMarker)
+	}
+	return original, nil
+}
+
+// GetClassicLoadBalancers returns all classic load balancers in the region
+func (l *LoadBalancerClient) GetClassicLoadBalancers(original []elbTypes.LoadBalancerDescription, marker *string) ([]elbTypes.LoadBalancerDescription, error) {
+	result, err := l.Classic.DescribeLoadBalancers(context.TODO(), &elb.DescribeLoadBalancersInput{
+		Marker: marker,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	original = append(original, result.LoadBalancerDescriptions...)
+	if result.NextMarker != nil {
+		return l.GetClassicLoadBalancers(original, result.NextMarker)
+	}
+	return original, nil
+}
+
+// GetAccessLogs returns whether access logs of application or network load balancer are enabled, and the bucket
+func (l *LoadBalancerClient) GetAccessLogs(arn *string) (bool, *string, error) {
+	result, err := l.Client.DescribeLoadBalancerAttributes(context.TODO(), &elbv2.DescribeLoadBalancerAttributesInput{
+		LoadBalancerArn: arn,
+	})
+	if err != nil {
+		return false, nil, err
+	}
+
+	var enabled bool
+	var bucket *string
+	for _, attribute := range result.Attributes {
+		switch aws.ToString(attribute.Key) {
+		case accessLogsEnabledAttribute:
+			enabled = aws.ToString(attribute.Value) == "true"
+		case accessLogsBucketAttribute:
+			bucket = attribute.Value
+		}
+	}
+
+	if !enabled {
+		return false, nil, nil
+	}
+	return true, bucket, nil
+}
+
+// GetClassicAccessLogs returns whether access logs of classic load balancer are enabled, and the bucket
+func (l *LoadBalancerClient) GetClassicAccessLogs(name *string) (bool, *string, error) {
+	result, err := l.Classic.DescribeLoadBalancerAttributes(context.TODO(), &elb.DescribeLoadBalancerAttributesInput{
+		LoadBalancerName: name,
+	})
+	if err != nil {
+		return false, nil, err
+	}
+
+	if result.LoadBalancerAttributes == nil || result.LoadBalancerAttributes.AccessLog == nil || !result.LoadBalancerAttributes.AccessLog.Enabled {
+		return false, nil, nil
+	}
+	return true, result.LoadBalancerAttributes.AccessLog.S3BucketName, nil
+}
+
+// SetAlias sets alias
+func (l *LoadBalancerClient) SetAlias(alias *string) {
+	l.Alias = alias
+}
@@ -0,0 +1,236 @@
+/*
+Copyright 2020 The redhawk Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/kafka"
+	"github.com/aws/aws-sdk-go-v2/service/kafka/types"
+	"github.com/sirupsen/logrus"
+
+	"github.com/DevopsArtFactory/redhawk/pkg/constants"
+	"github.com/DevopsArtFactory/redhawk/pkg/resource"
+)
+
+type MSKClient struct {
+	Resource string
+	Client   *kafka.Client
+	Region   string
+	Alias    *string
+}
+
+// GetResourceName returns resource name of client
+func (m *MSKClient) GetResourceName() string {
+	return m.Resource
+}
+
+// NewMSKClient creates a MSKClient
+func NewMSKClient(cfg aws.Config, helper Helper) (Client, error) {
+	return &MSKClient{
+		Resource: constants.MSKResourceName,
+		Client:   GetMSKClientFn(cfg),
+		Region:   helper.Region,
+	}, nil
+}
+
+// GetMSKClientFn creates kafka client
+func GetMSKClientFn(cfg aws.Config) *kafka.Client {
+	return kafka.NewFromConfig(cfg)
+}
+
+// Scan scans all data
+func (m *MSKClient) Scan() ([]resource.Resource, error) {
+	var wg sync.WaitGroup
+	var result []resource.Resource
+
+	logrus.Debug("Start scanning all MSK clusters in the region")
+	clusters, err := m.GetClusterList(nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(clusters) == 0 {
+		logrus.Debug("no MSK cluster found")
+		return nil, nil
+	}
+
+	input := make(chan *resource.MSKResource)
+	output := make(chan []resource.Resource)
+	defer close(output)
+
+	go func(input chan *resource.MSKResource, output chan []resource.Resource, wg *sync.WaitGroup) {
+		var ret []resource.Resource
+		for result := range input {
+			if result != nil {
+				ret = append(ret, *result)
+			}
+			wg.Done()
+		}
+
+		output <- ret
+	}(input, output, &wg)
+
+	f := func(cluster types.ClusterInfo, ch chan *resource.MSKResource) {
+		tmp := resource.MSKResource{
+			ResourceType: aws.String(constants.MSKResourceName),
+		}
+
+		tmp.ClusterName = cluster.ClusterName
+		tmp.Region = aws.String(m.Region)
+		tmp.State = aws.String(string(cluster.State))
+		tmp.BrokerCount = aws.Int(int(cluster.NumberOfBrokerNodes))
+		tmp.Created = cluster.CreationTime
+		tmp.Tags = cluster.Tags
+		tmp.RetentionHours = aws.Int(constants.DefaultMSKRetentionHours)
+
+		if cluster.BrokerNodeGroupInfo != nil {
+			tmp.InstanceType = cluster.BrokerNodeGroupInfo.InstanceType
+			tmp.PublicAccess = aws.String("DISABLED")
+			ci := cluster.BrokerNodeGroupInfo.ConnectivityInfo
+			if ci != nil && ci.PublicAccess != nil && ci.PublicAccess.Type != nil {
+				tmp.PublicAccess = ci.PublicAccess.Type
+			}
+		}
+
+		if cluster.EncryptionInfo != nil {
+			if cluster.EncryptionInfo.EncryptionAtRest != nil {
+				tmp.AtRestKmsKeyID = cluster.EncryptionInfo.EncryptionAtRest.DataVolumeKMSKeyId
+			}
+
+			if cluster.EncryptionInfo.EncryptionInTransit != nil {
+				tmp.InTransitClientBroker = aws.String(string(cluster.EncryptionInfo.EncryptionInTransit.ClientBroker))
+				tmp.InTransitInCluster = aws.Bool(cluster.EncryptionInfo.EncryptionInTransit.InCluster)
+			}
+		}
+
+		if sw := cluster.CurrentBrokerSoftwareInfo; sw != nil {
+			tmp.KafkaVersion = sw.KafkaVersion
+
+			if sw.ConfigurationArn != nil {
+				properties, err := m.GetServerProperties(*sw.ConfigurationArn, sw.ConfigurationRevision)
+				if err != nil {
+					logrus.Error(err.Error())
+					ch <- nil
+					return
+				}
+
+				if retention := parseKafkaRetentionHours(properties); retention != nil {
+					tmp.RetentionHours = retention
+				}
+			}
+		}
+
+		logrus.Tracef("MSK cluster is added: %s", *tmp.ClusterName)
+		ch <- &tmp
+	}
+
+	logrus.Debugf("MSK clusters found: %d", len(clusters))
+	for _, cluster := range clusters {
+		wg.Add(1)
+		go f(cluster, input)
+	}
+
+	wg.Wait()
+	close(input)
+
+	result = <-output
+	logrus.Debugf("total valid MSK data count: %d", len(result))
+
+	return result, nil
+}
+
+// GetClusterList returns all MSK clusters in the region
+func (m *MSKClient) GetClusterList(original []types.ClusterInfo, nextToken *string) ([]types.ClusterInfo, error) {
+	result, err := m.Client.ListClusters(context.TODO(), &kafka.ListClustersInput{
+		NextToken: nextToken,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	original = append(original, result.ClusterInfoList...)
+	if result.NextToken != nil {
+		return m.GetClusterList(original, result.NextToken)
+	}
+	return original, nil
+}
+
+// GetServerProperties returns server.properties of the configuration revision
+func (m *MSKClient) GetServerProperties(arn string, revision int64) (string, error) {
+	result, err := m.Client.DescribeConfigurationRevision(context.TODO(), &kafka.DescribeConfigurationRevisionInput{
+		Arn:      aws.String(arn),
+		Revision: revision,
+	})
+	if err != nil {
+		return constants.EmptyString, err
+	}
+
+	return string(result.ServerProperties), nil
+}
+
+// SetAlias sets alias
+func (m *MSKClient) SetAlias(alias *string) {
+	m.Alias = alias
+}
+
+// parseKafkaRetentionHours finds log retention in server.properties.
+// Kafka prefers log.retention.ms over minutes, and minutes over hours.
+// Any negative value means unlimited retention and is returned as -1.
+// Retention is rounded up to hours, so short retention is not reported as 0.
+func parseKafkaRetentionHours(properties string) *int {
+	values := map[string]int64{}
+	for _, line := range strings.Split(properties, "\n") {
+		kv := strings.SplitN(strings.TrimSpace(line), "=", 2)
+		if len(kv) != 2 {
+			continue
+		}
+
+		v, err := strconv.ParseInt(strings.TrimSpace(kv[1]), 10, 64)
+		if err != nil {
+			continue
+		}
+		values[strings.TrimSpace(kv[0])] = v
+	}
+
+	units := []struct {
+		key     string
+		perHour int64
+	}{
+		{key: "log.retention.ms", perHour: 3600000},
+		{key: "log.retention.minutes", perHour: 60},
+		{key: "log.retention.hours", perHour: 1},
+	}
+
+	for _, unit := range units {
+		v, ok := values[unit.key]
+		if !ok {
+			continue
+		}
+
+		if v < 0 {
+			return aws.Int(-1)
+		}
+		return aws.Int(int((v + unit.perHour - 1) / unit.perHour))
+	}
+
+	return nil
+}
@@ -0,0 +1,48 @@
+/*
+Copyright 2020 The redhawk Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+)
+
+func TestParseKafkaRetentionHours(t *testing.T) {
+	tests := []struct {
+		description string
+		properties  string
+		expected    *int
+	}{
+		{description: "no retention", properties: "auto.create.topics.enable=true", expected: nil},
+		{description: "hours", properties: "log.retention.hours=72", expected: aws.Int(72)},
+		{description: "minutes over hours", properties: "log.retention.hours=72\nlog.retention.minutes=90", expected: aws.Int(2)},
+		{description: "ms over minutes", properties: "log.retention.minutes=90\nlog.retention.ms=7200000", expected: aws.Int(2)},
+		{description: "ms shorter than an hour", properties: "log.retention.ms=600000", expected: aws.Int(1)},
+		{description: "unlimited ms", properties: "log.retention.ms=-1", expected: aws.Int(-1)},
+		{description: "unlimited minutes", properties: "log.retention.minutes=-1", expected: aws.Int(-1)},
+		{description: "unlimited hours", properties: " log.retention.hours = -1 ", expected: aws.Int(-1)},
+		{description: "invalid value", properties: "log.retention.ms=forever\nlog.retention.hours=24", expected: aws.Int(24)},
+	}
+
+	for _, test := range tests {
+		got := parseKafkaRetentionHours(test.properties)
+		if (got == nil) != (test.expected == nil) || aws.ToInt(got) != aws.ToInt(test.expected) {
+			t.Errorf("%s: expected %d, got %d", test.description, aws.ToInt(test.expected), aws.ToInt(got))
+		}
+	}
+}
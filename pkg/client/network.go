@@ -0,0 +1,259 @@
+/*
+Copyright 2020 The redhawk Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+
+	"github.com/DevopsArtFactory/redhawk/pkg/constants"
+	"github.com/DevopsArtFactory/redhawk/pkg/tools"
+)
+
+// protocolNumbers are IANA numbers of protocols used in network ACL
+var protocolNumbers = map[string]string{
+	constants.SGRuleAllProtocols: "-1",
+	"tcp":                        "6",
+	"udp":                        "17",
+	"icmp":                       "1",
+	"icmpv6":                     "58",
+}
+
+// PortRange is a range of ports. All ports are -1
+type PortRange struct {
+	Protocol string
+	From     int
+	To       int
+}
+
+// String returns port range for printing
+func (p PortRange) String() string {
+	r := formatPortRange(p.Protocol, p.From, p.To)
+	if p.Protocol == constants.SGRuleAllProtocols || p.Protocol == "tcp" {
+		return r
+	}
+	return fmt.Sprintf("%s/%s", p.Protocol, r)
+}
+
+// Contains checks if the port is in the range
+func (p PortRange) Contains(protocol string, port int) bool {
+	if p.Protocol != constants.SGRuleAllProtocols && protocolNumber(p.Protocol) != protocolNumber(protocol) {
+		return false
+	}
+	return p.From < 0 || (port >= p.From && port <= p.To)
+}
+
+// probes returns ports to check reachability of the range
+func (p PortRange) probes() []int {
+	ports := []int{p.From}
+	if p.From < 0 {
+		ports = []int{}
+	}
+
+	candidates := append([]int{}, constants.WebPorts...)
+	for port := range constants.SensitivePorts {
+		candidates = append(candidates, port)
+	}
+	sort.Ints(candidates)
+
+	for _, port := range candidates {
+		if p.From < 0 || (port > p.From && port <= p.To) {
+			ports = append(ports, port)
+		}
+	}
+	return ports
+}
+
+// NetworkIndex answers reachability questions with route tables, network ACLs and security groups of a region
+type NetworkIndex struct {
+	RouteTables    []types.RouteTable
+	NetworkACLs    []types.NetworkAcl
+	SecurityGroups map[string]types.SecurityGroup
+}
+
+// NewNetworkIndex creates a NetworkIndex
+func NewNetworkIndex(routeTables []types.RouteTable, networkACLs []types.NetworkAcl, securityGroups []types.SecurityGroup) *NetworkIndex {
+	sgs := map[string]types.SecurityGroup{}
+	for _, sg := range securityGroups {
+		sgs[aws.ToString(sg.GroupId)] = sg
+	}
+
+	return &NetworkIndex{
+		RouteTables:    routeTables,
+		NetworkACLs:    networkACLs,
+		SecurityGroups: sgs,
+	}
+}
+
+// HasInternetRoute checks if the route table of subnet has a default route to internet gateway.
+// The main route table of VPC is applied to subnets without explicit association.
+func (n *NetworkIndex) HasInternetRoute(vpcID, subnetID string) bool {
+	var table *types.RouteTable
+	for i, rt := range n.RouteTables {
+		for _, a := range rt.Associations {
+			if aws.ToString(a.SubnetId) == subnetID {
+				table = &n.RouteTables[i]
+			} else if table == nil && aws.ToBool(a.Main) && aws.ToString(rt.VpcId) == vpcID {
+				table = &n.RouteTables[i]
+			}
+		}
+	}
+
+	if table == nil {
+		return false
+	}
+
+	for _, route := range table.Routes {
+		if !strings.HasPrefix(aws.ToString(route.GatewayId), "igw-") {
+			continue
+		}
+
+		if route.State != types.RouteStateActive && route.State != constants.EmptyString {
+			continue
+		}
+
+		if tools.IsStringInArray(aws.ToString(route.DestinationCidrBlock), constants.WorldOpenCIDRs) ||
+			tools.IsStringInArray(aws.ToString(route.DestinationIpv6CidrBlock), constants.WorldOpenCIDRs) {
+			return true
+		}
+	}
+	return false
+}
+
+// NetworkACLAllows checks if the network ACL of subnet allows inbound traffic from internet to the port.
+// Only entries for the whole internet are evaluated, in order of rule number.
+func (n *NetworkIndex) NetworkACLAllows(vpcID, subnetID, protocol string, port int) bool {
+	var acl *types.NetworkAcl
+	for i, a := range n.NetworkACLs {
+		for _, assoc := range a.Associations {
+			if aws.ToString(assoc.SubnetId) == subnetID {
+				acl = &n.NetworkACLs[i]
+			}
+		}
+
+		if acl == nil && aws.ToBool(a.IsDefault) && aws.ToString(a.VpcId) == vpcID {
+			acl = &n.NetworkACLs[i]
+		}
+	}
+
+	// Network ACL is unknown
+	if acl == nil {
+		return true
+	}
+
+	entries := append([]types.NetworkAclEntry{}, acl.Entries...)
+	sort.Slice(entries, func(i, j int) bool {
+		return aws.ToInt32(entries[i].RuleNumber) < aws.ToInt32(entries[j].RuleNumber)
+	})
+
+	for _, e := range entries {
+		if aws.ToBool(e.Egress) {
+			continue
+		}
+
+		if !tools.IsStringInArray(aws.ToString(e.CidrBlock), constants.WorldOpenCIDRs) &&
+			!tools.IsStringInArray(aws.ToString(e.Ipv6CidrBlock), constants.WorldOpenCIDRs) {
+			continue
+		}
+
+		if p := aws.ToString(e.Protocol); p != "-1" && p != protocolNumber(protocol) {
+			continue
+		}
+
+		if e.PortRange != nil && (port < int(aws.ToInt32(e.PortRange.From)) || port > int(aws.ToInt32(e.PortRange.To))) {
+			continue
+		}
+
+		return e.RuleAction == types.RuleActionAllow
+	}
+
+	return false
+}
+
+// WorldOpenPorts returns port ranges open to the internet by the security groups
+func (n *NetworkIndex) WorldOpenPorts(groupIDs []string) []PortRange {
+	var ret []PortRange
+	seen := map[PortRange]bool{}
+	for _, id := range groupIDs {
+		sg, ok := n.SecurityGroups[id]
+		if !ok {
+			continue
+		}
+
+		for _, r := range SGRules(sg) {
+			if *r.Direction != constants.SGRuleIngress || !*r.WorldOpen {
+				continue
+			}
+
+			p := PortRange{Protocol: *r.Protocol, From: -1, To: -1}
+			if r.FromPort != nil && *r.PortRange != constants.SGRuleAllProtocols {
+				p.From, p.To = *r.FromPort, *r.ToPort
+			}
+
+			if !seen[p] {
+				seen[p] = true
+				ret = append(ret, p)
+			}
+		}
+	}
+
+	return ret
+}
+
+// ReachablePorts returns port ranges which are reachable from internet through any subnet.
+// Reachability of a range is checked with its first port and well-known ports in it.
+func (n *NetworkIndex) ReachablePorts(vpcID string, subnets []string, ranges []PortRange) []PortRange {
+	var ret []PortRange
+	for _, r := range ranges {
+		protocol := r.Protocol
+		if protocol == constants.SGRuleAllProtocols {
+			protocol = "tcp"
+		}
+
+		reachable := false
+		for _, subnet := range subnets {
+			for _, port := range r.probes() {
+				if n.NetworkACLAllows(vpcID, subnet, protocol, port) {
+					reachable = true
+					break
+				}
+			}
+
+			if reachable {
+				break
+			}
+		}
+
+		if reachable {
+			ret = append(ret, r)
+		}
+	}
+
+	return ret
+}
+
+// protocolNumber converts protocol name to number
+func protocolNumber(protocol string) string {
+	if n, ok := protocolNumbers[protocol]; ok {
+		return n
+	}
+	return protocol
+}
@@ -0,0 +1,477 @@
+/*
+Copyright 2020 The redhawk Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/organizations"
+	"github.com/aws/aws-sdk-go-v2/service/organizations/types"
+	"github.com/sirupsen/logrus"
+
+	"github.com/DevopsArtFactory/redhawk/pkg/constants"
+	"github.com/DevopsArtFactory/redhawk/pkg/resource"
+	"github.com/DevopsArtFactory/redhawk/pkg/schema"
+)
+
+// organizationAccounts keeps account IDs of organizations so that accounts are listed once per run
+var organizationAccounts sync.Map
+
+type OrganizationsClient struct {
+	Resource string
+	Client   *organizations.Client
+	Alias    *string
+}
+
+// GetResourceName returns resource name of client
+func (o *OrganizationsClient) GetResourceName() string {
+	return o.Resource
+}
+
+// NewOrganizationsClient creates a OrganizationsClient
+func NewOrganizationsClient(cfg aws.Config, _ Helper) (Client, error) {
+	return &OrganizationsClient{
+		Resource: constants.OrganizationResourceName,
+		Client:   GetOrganizationsClientFn(cfg),
+	}, nil
+}
+
+// GetOrganizationsClientFn creates organizations client
+func GetOrganizationsClientFn(cfg aws.Config) *organizations.Client {
+	return organizations.NewFromConfig(cfg)
+}
+
+// Scan scans all data
+func (o *OrganizationsClient) Scan() ([]resource.Resource, error) {
+	var result []resource.Resource
+
+	logrus.Debug("Start scanning organization structure")
+	roots, err := o.GetRoots()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(roots) == 0 {
+		logrus.Debug("no organization root found")
+		return nil, nil
+	}
+
+	// name of parents for accounts and OUs
+	parentNames := map[string]string{}
+	var units []types.OrganizationalUnit
+	unitParents := map[string]string{}
+	accountParents := map[string]string{}
+	accountCount := map[string]int{}
+
+	var walk func(parentID string) error
+	walk = func(parentID string) error {
+		accounts, err := o.GetAccountsForParent(parentID, nil, nil)
+		if err != nil {
+			return err
+		}
+
+		for _, account := range accounts {
+			accountParents[*account.Id] = parentID
+		}
+		accountCount[parentID] = len(accounts)
+
+		children, err := o.GetOrganizationalUnits(parentID, nil, nil)
+		if err != nil {
+			return err
+		}
+
+		for _, child := range children {
+			units = append(units, child)
+			unitParents[*child.Id] = parentID
+			parentNames[*child.Id] = *child.Name
+			if err := walk(*child.Id); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	for _, root := range roots {
+		parentNames[*root.Id] = *root.Name
+		if err := walk(*root.Id); err != nil {
+			return nil, err
+		}
+	}
+
+	accounts, err := o.GetAccounts(nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// SCP names attached to each target and targets of each SCP
+	targetPolicies := map[string][]string{}
+	policyTargets := map[string][]string{}
+	scpEnabled := true
+
+	var targets []string
+	for _, root := range roots {
+		targets = append(targets, *root.Id)
+	}
+	for _, unit := range units {
+		targets = append(targets, *unit.Id)
+	}
+	for _, account := range accounts {
+		targets = append(targets, *account.Id)
+	}
+
+	for _, target := range targets {
+		policies, err := o.GetServiceControlPoliciesForTarget(target, nil, nil)
+		if err != nil {
+			var notEnabled *types.PolicyTypeNotEnabledException
+			if errors.As(err, &notEnabled) {
+				logrus.Debug("service control policy is not enabled in the organization")
+				scpEnabled = false
+				break
+			}
+			return nil, err
+		}
+
+		for _, policy := range policies {
+			targetPolicies[target] = append(targetPolicies[target], *policy.Name)
+			policyTargets[*policy.Id] = append(policyTargets[*policy.Id], target)
+		}
+	}
+
+	for _, account := range accounts {
+		tmp := resource.OrganizationAccountResource{
+			ResourceType: aws.String(constants.OrganizationAccountResourceName),
+		}
+
+		tmp.AccountID = account.Id
+		tmp.Name = account.Name
+		tmp.Email = account.Email
+		tmp.Status = aws.String(string(account.Status))
+		tmp.JoinedMethod = aws.String(string(account.JoinedMethod))
+		tmp.Joined = account.JoinedTimestamp
+		tmp.SCPs = aws.String(strings.Join(targetPolicies[*account.Id], constants.DefaultDelimiter))
+
+		if parent, ok := accountParents[*account.Id]; ok {
+			tmp.ParentID = aws.String(parent)
+			tmp.ParentName = aws.String(parentNames[parent])
+		}
+
+		result = append(result, tmp)
+	}
+
+	for _, unit := range units {
+		tmp := resource.OrganizationUnitResource{
+			ResourceType: aws.String(constants.OrganizationUnitResourceName),
+		}
+
+		tmp.OUID = unit.Id
+		tmp.Name = unit.Name
+		tmp.ParentID = aws.String(unitParents[*unit.Id])
+		tmp.AccountCount = aws.Int(accountCount[*unit.Id])
+		tmp.SCPs = aws.String(strings.Join(targetPolicies[*unit.Id], constants.DefaultDelimiter))
+
+		result = append(result, tmp)
+	}
+
+	if scpEnabled {
+		policies, err := o.GetServiceControlPolicies(nil, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, policy := range policies {
+			tmp := resource.OrganizationPolicyResource{
+				ResourceType: aws.String(constants.OrganizationPolicyResourceName),
+			}
+
+			tmp.PolicyID = policy.Id
+			tmp.Name = policy.Name
+			tmp.AWSManaged = aws.Bool(policy.AwsManaged)
+			tmp.Description = policy.Description
+			tmp.Targets = aws.String(strings.Join(policyTargets[*policy.Id], constants.DefaultDelimiter))
+
+			result = append(result, tmp)
+		}
+	}
+
+	logrus.Debugf("total valid organization data count: %d", len(result))
+
+	return result, nil
+}
+
+// GetRoots returns roots of the organization
+func (o *OrganizationsClient) GetRoots() ([]types.Root, error) {
+	result, err := o.Client.ListRoots(context.TODO(), &organizations.ListRootsInput{})
+	if err != nil {
+		return nil, err
+	}
+
+	return result.Roots, nil
+}
+
+// GetAccounts returns all accounts in the organization
+func (o *OrganizationsClient) GetAccounts(original []types.Account, nextToken *string) ([]types.Account, error) {
+	result, err := o.Client.ListAccounts(context.TODO(), &organizations.ListAccountsInput{
+		NextToken: nextToken,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	original = append(original, result.Accounts...)
+	if result.NextToken != nil {
+		return o.GetAccounts(original, result.NextToken)
+	}
+	return original, nil
+}
+
+// GetAccountsForParent returns accounts directly under the root or OU
+func (o *OrganizationsClient) GetAccountsForParent(parentID string, original []types.Account, nextToken *string) ([]types.Account, error) {
+	result, err := o.Client.ListAccountsForParent(context.TODO(), &organizations.ListAccountsForParentInput{
+		ParentId:  aws.String(parentID),
+		NextToken: nextToken,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	original = append(original, result.Accounts...)
+	if result.NextToken != nil {
+		return o.GetAccountsForParent(parentID, original, result.NextToken)
+	}
+	return original, nil
+}
+
+// GetOrganizationalUnits returns OUs directly under the root or OU
+func (o *OrganizationsClient) GetOrganizationalUnits(parentID string, original []types.OrganizationalUnit, nextToken *string) ([]types.OrganizationalUnit, error) {
+	result, err := o.Client.ListOrganizationalUnitsForParent(context.TODO(), &organizations.ListOrganizationalUnitsForParentInput{
+		ParentId:  aws.String(parentID),
+		NextToken: nextToken,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	original = append(original, result.OrganizationalUnits...)
+	if result.NextToken != nil {
+		return o.GetOrganizationalUnits(parentID, original, result.NextToken)
+	}
+	return original, nil
+}
+
+// GetServiceControlPolicies returns all service control policies
+func (o *OrganizationsClient) GetServiceControlPolicies(original []types.PolicySummary, nextToken *string) ([]types.PolicySummary, error) {
+	result, err := o.Client.ListPolicies(context.TODO(), &organizations.ListPoliciesInput{
+		Filter:    types.PolicyTypeServiceControlPolicy,
+		NextToken: nextToken,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	original = append(original, result.Policies...)
+	if result.NextToken != nil {
+		return o.GetServiceControlPolicies(original, result.NextToken)
+	}
+	return original, nil
+}
+
+// GetServiceControlPoliciesForTarget returns service control policies attached to the target
+func (o *OrganizationsClient) GetServiceControlPoliciesForTarget(targetID string, original []types.PolicySummary, nextToken *string) ([]types.PolicySummary, error) {
+	result, err := o.Client.ListPoliciesForTarget(context.TODO(), &organizations.ListPoliciesForTargetInput{
+		Filter:    types.PolicyTypeServiceControlPolicy,
+		TargetId:  aws.String(targetID),
+		NextToken: nextToken,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	original = append(original, result.Policies...)
+	if result.NextToken != nil {
+		return o.GetServiceControlPoliciesForTarget(targetID, original, result.NextToken)
+	}
+	return original, nil
+}
+
+// GetActiveAccounts returns active accounts in the organization.
+// If OUs are specified, only accounts in the OUs and their child OUs are returned
+func (o *OrganizationsClient) GetActiveAccounts(ous []string) ([]types.Account, error) {
+	var accounts []types.Account
+	if len(ous) == 0 {
+		all, err := o.GetAccounts(nil, nil)
+		if err != nil {
+			return nil, err
+		}
+		accounts = all
+	} else {
+		found, err := collectOUAccounts(ous, func(parentID string) ([]types.Account, error) {
+			return o.GetAccountsForParent(parentID, nil, nil)
+		}, func(parentID string) ([]types.OrganizationalUnit, error) {
+			return o.GetOrganizationalUnits(parentID, nil, nil)
+		})
+		if err != nil {
+			return nil, err
+		}
+		accounts = found
+	}
+
+	return filterActiveAccounts(accounts), nil
+}
+
+// collectOUAccounts returns accounts in the OUs and all of their child OUs
+func collectOUAccounts(ous []string, accountsFor func(string) ([]types.Account, error), childrenOf func(string) ([]types.OrganizationalUnit, error)) ([]types.Account, error) {
+	var accounts []types.Account
+
+	var collect func(parentID string) error
+	collect = func(parentID string) error {
+		found, err := accountsFor(parentID)
+		if err != nil {
+			return err
+		}
+		accounts = append(accounts, found...)
+
+		children, err := childrenOf(parentID)
+		if err != nil {
+			return err
+		}
+
+		for _, child := range children {
+			if err := collect(*child.Id); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	for _, ou := range ous {
+		if err := collect(ou); err != nil {
+			return nil, err
+		}
+	}
+
+	return accounts, nil
+}
+
+// filterActiveAccounts returns active accounts without duplicates
+func filterActiveAccounts(accounts []types.Account) []types.Account {
+	var ret []types.Account
+	seen := map[string]bool{}
+	for _, account := range accounts {
+		if account.Status != types.AccountStatusActive || seen[*account.Id] {
+			continue
+		}
+		seen[*account.Id] = true
+		ret = append(ret, account)
+	}
+
+	return ret
+}
+
+// SetAlias sets alias
+func (o *OrganizationsClient) SetAlias(alias *string) {
+	o.Alias = alias
+}
+
+// DiscoverAccounts returns accounts to scan from the organization of current credentials
+func DiscoverAccounts(org schema.Organization) ([]schema.Account, error) {
+	if !strings.Contains(org.RoleTemplate, constants.AccountIDPlaceholder) {
+		return nil, fmt.Errorf("role_template of organization should contain %s: %s", constants.AccountIDPlaceholder, org.RoleTemplate)
+	}
+
+	o := OrganizationsClient{
+		Client: GetOrganizationsClientFn(GetAwsSession(constants.DefaultRegion)),
+	}
+
+	accounts, err := o.GetActiveAccounts(org.OrganizationalUnits)
+	if err != nil {
+		return nil, err
+	}
+
+	var ret []schema.Account
+	for _, account := range accounts {
+		ret = append(ret, schema.Account{
+			Name:    *account.Name,
+			RoleArn: strings.ReplaceAll(org.RoleTemplate, constants.AccountIDPlaceholder, *account.Id),
+		})
+	}
+	logrus.Debugf("accounts discovered from organization: %d", len(ret))
+
+	return ret, nil
+}
+
+// GetOrganizationAccountIDs returns ID of the organization and IDs of accounts in the organization.
+// Member accounts cannot list accounts, so default credentials are used for listing
+// if they belong to the same organization. Empty ID is returned if account is not in any organization
+func GetOrganizationAccountIDs(cfg aws.Config) (string, map[string]bool, error) {
+	o := OrganizationsClient{
+		Client: GetOrganizationsClientFn(cfg),
+	}
+
+	org, err := o.DescribeOrganization()
+	if err != nil {
+		var notInUse *types.AWSOrganizationsNotInUseException
+		if errors.As(err, &notInUse) {
+			return constants.EmptyString, nil, nil
+		}
+		return constants.EmptyString, nil, err
+	}
+
+	if cached, ok := organizationAccounts.Load(*org.Id); ok {
+		return *org.Id, cached.(map[string]bool), nil
+	}
+
+	accounts, err := o.GetAccounts(nil, nil)
+	if err != nil {
+		logrus.Debugf("cannot list accounts of organization with current credentials: %s", err.Error())
+
+		o.Client = GetOrganizationsClientFn(GetAwsSession(constants.DefaultRegion))
+		defaultOrg, orgErr := o.DescribeOrganization()
+		if orgErr != nil || *defaultOrg.Id != *org.Id {
+			return *org.Id, nil, nil
+		}
+
+		accounts, err = o.GetAccounts(nil, nil)
+		if err != nil {
+			logrus.Debugf("cannot list accounts of organization: %s", err.Error())
+			return *org.Id, nil, nil
+		}
+	}
+
+	ids := map[string]bool{}
+	for _, account := range accounts {
+		ids[*account.Id] = true
+	}
+	organizationAccounts.Store(*org.Id, ids)
+
+	return *org.Id, ids, nil
+}
+
+// DescribeOrganization returns organization of the account
+func (o *OrganizationsClient) DescribeOrganization() (*types.Organization, error) {
+	result, err := o.Client.DescribeOrganization(context.TODO(), &organizations.DescribeOrganizationInput{})
+	if err != nil {
+		return nil, err
+	}
+
+	return result.Organization, nil
+}
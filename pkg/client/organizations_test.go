@@ -0,0 +1,87 @@
+/*
+Copyright 2020 The redhawk Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/organizations/types"
+)
+
+func TestCollectOUAccounts(t *testing.T) {
+	accounts := map[string][]types.Account{
+		"ou-a":   {{Id: aws.String("111111111111")}},
+		"ou-a-1": {{Id: aws.String("222222222222")}},
+		"ou-a-2": {{Id: aws.String("333333333333")}},
+		"ou-b":   {{Id: aws.String("444444444444")}},
+	}
+	children := map[string][]types.OrganizationalUnit{
+		"ou-a":   {{Id: aws.String("ou-a-1")}, {Id: aws.String("ou-a-2")}},
+		"ou-a-1": {{Id: aws.String("ou-a-1-x")}},
+	}
+	accountsFor := func(parentID string) ([]types.Account, error) {
+		return accounts[parentID], nil
+	}
+	childrenOf := func(parentID string) ([]types.OrganizationalUnit, error) {
+		return children[parentID], nil
+	}
+
+	found, err := collectOUAccounts([]string{"ou-a"}, accountsFor, childrenOf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var ids []string
+	for _, account := range found {
+		ids = append(ids, *account.Id)
+	}
+	expected := []string{"111111111111", "222222222222", "333333333333"}
+	if !reflect.DeepEqual(ids, expected) {
+		t.Errorf("expected %v, got %v", expected, ids)
+	}
+
+	failing := func(parentID string) ([]types.OrganizationalUnit, error) {
+		if parentID == "ou-a-1" {
+			return nil, errors.New("access denied")
+		}
+		return children[parentID], nil
+	}
+	if _, err := collectOUAccounts([]string{"ou-a"}, accountsFor, failing); err == nil {
+		t.Error("expected error from child OU to be returned")
+	}
+}
+
+func TestFilterActiveAccounts(t *testing.T) {
+	accounts := []types.Account{
+		{Id: aws.String("111111111111"), Status: types.AccountStatusActive},
+		{Id: aws.String("222222222222"), Status: types.AccountStatusSuspended},
+		{Id: aws.String("111111111111"), Status: types.AccountStatusActive},
+		{Id: aws.String("333333333333"), Status: types.AccountStatusActive},
+	}
+
+	var ids []string
+	for _, account := range filterActiveAccounts(accounts) {
+		ids = append(ids, *account.Id)
+	}
+	expected := []string{"111111111111", "333333333333"}
+	if !reflect.DeepEqual(ids, expected) {
+		t.Errorf("expected %v, got %v", expected, ids)
+	}
+}
@@ -19,7 +19,6 @@ package client
 import (
 	"context"
 	"strings"
-	"sync"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/rds"
@@ -54,101 +53,120 @@ func GetRDSClientFn(cfg aws.Config) *rds.Client {
 	return rds.NewFromConfig(cfg)
 }
 
-// Scan scans all data
+// Scan scans all DB instances. Settings of Aurora clusters are applied to their instances
 func (r *RDSClient) Scan() ([]resource.Resource, error) {
-	var wg sync.WaitGroup
 	var result []resource.Resource
 
 	clusters, err := r.GetRDSClusterList()
 	if err != nil {
 		return nil, err
 	}
+	logrus.Debugf("RDS clusters found: %d", len(clusters))
+
+	clusterByID := map[string]types.DBCluster{}
+	roles := map[string]string{}
+	for _, cluster := range clusters {
+		clusterByID[aws.ToString(cluster.DBClusterIdentifier)] = cluster
+		for _, member := range cluster.DBClusterMembers {
+			role := "reader"
+			if member.IsClusterWriter {
+				role = "writer"
+			}
+			roles[aws.ToString(member.DBInstanceIdentifier)] = role
+		}
+	}
 
-	if len(clusters) == 0 {
-		logrus.Debug("no RDS cluster found")
+	instances, err := r.GetDBInstances(nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(instances) == 0 {
+		logrus.Debug("no RDS instance found")
 		return nil, nil
 	}
 
-	input := make(chan *resource.RDSResource)
-	output := make(chan []resource.Resource)
-	defer close(output)
+	for _, dbInfo := range instances {
+		tmp := resource.RDSResource{
+			ResourceType:            aws.String(constants.RDSResourceName),
+			RDSIdentifier:           dbInfo.DBInstanceIdentifier,
+			Cluster:                 dbInfo.DBClusterIdentifier,
+			Engine:                  dbInfo.Engine,
+			EngineVersion:           dbInfo.EngineVersion,
+			EndOfSupport:            RDSEndOfSupport(aws.ToString(dbInfo.Engine), aws.ToString(dbInfo.EngineVersion)),
+			AvailabilityZone:        dbInfo.AvailabilityZone,
+			Size:                    dbInfo.DBInstanceClass,
+			Status:                  dbInfo.DBInstanceStatus,
+			StorageType:             dbInfo.StorageType,
+			StorageEncrypted:        aws.Bool(dbInfo.StorageEncrypted),
+			PubliclyAccessible:      aws.Bool(dbInfo.PubliclyAccessible),
+			BackupRetentionPeriod:   aws.Int(int(dbInfo.BackupRetentionPeriod)),
+			DeletionProtection:      aws.Bool(dbInfo.DeletionProtection),
+			MultiAZ:                 aws.Bool(dbInfo.MultiAZ),
+			IAMAuthEnabled:          aws.Bool(dbInfo.IAMDatabaseAuthenticationEnabled),
+			AutoMinorVersionUpgrade: aws.Bool(dbInfo.AutoMinorVersionUpgrade),
+			Created:                 dbInfo.InstanceCreateTime,
+		}
 
-	go func(input chan *resource.RDSResource, output chan []resource.Resource, wg *sync.WaitGroup) {
-		var ret []resource.Resource
-		for result := range input {
-			if result != nil {
-				ret = append(ret, *result)
+		// Standalone instances are writers unless they are read replicas
+		role, ok := roles[aws.ToString(dbInfo.DBInstanceIdentifier)]
+		if !ok {
+			role = "writer"
+			if dbInfo.ReadReplicaSourceDBInstanceIdentifier != nil {
+				role = "reader"
 			}
-			wg.Done()
 		}
+		tmp.Role = aws.String(role)
 
-		output <- ret
-	}(input, output, &wg)
-
-	f := func(cluster types.DBCluster, ch chan *resource.RDSResource) {
-		for _, dbMember := range cluster.DBClusterMembers {
-			tmp := resource.RDSResource{
-				ResourceType: aws.String(constants.RDSResourceName),
+		// Storage, backup, deletion protection and IAM authentication of Aurora are managed by the cluster
+		if cluster, ok := clusterByID[aws.ToString(dbInfo.DBClusterIdentifier)]; ok {
+			tmp.StorageEncrypted = aws.Bool(cluster.StorageEncrypted)
+			if cluster.BackupRetentionPeriod != nil {
+				tmp.BackupRetentionPeriod = aws.Int(int(*cluster.BackupRetentionPeriod))
 			}
-
-			tmp.RDSIdentifier = dbMember.DBInstanceIdentifier
-			role := "reader"
-			if dbMember.IsClusterWriter {
-				role = "writer"
+			if cluster.DeletionProtection != nil {
+				tmp.DeletionProtection = cluster.DeletionProtection
 			}
-
-			tmp.Role = aws.String(role)
-			tmp.Engine = cluster.Engine
-			tmp.EngineVersion = cluster.EngineVersion
-
-			dbInfo, err := r.GetRDSInfo(*dbMember.DBInstanceIdentifier)
-			if err != nil {
-				logrus.Error(err.Error())
-				ch <- nil
+			if cluster.MultiAZ != nil {
+				tmp.MultiAZ = cluster.MultiAZ
 			}
+			if cluster.IAMDatabaseAuthenticationEnabled != nil {
+				tmp.IAMAuthEnabled = cluster.IAMDatabaseAuthenticationEnabled
+			}
+		}
 
-			tmp.AvailabilityZone = dbInfo.AvailabilityZone
-			tmp.Size = dbInfo.DBInstanceClass
-			tmp.Status = dbInfo.DBInstanceStatus
+		if dbInfo.DBSubnetGroup != nil {
 			tmp.VPC = dbInfo.DBSubnetGroup.VpcId
-			tmp.StorageType = dbInfo.StorageType
 			tmp.DBSubnet = dbInfo.DBSubnetGroup.DBSubnetGroupName
-			tmp.Created = dbInfo.InstanceCreateTime
-
-			var sgList []string
-			for _, vpcSgID := range dbInfo.VpcSecurityGroups {
-				sgList = append(sgList, *vpcSgID.VpcSecurityGroupId)
-			}
-			tmp.SecurityGroup = aws.String(strings.Join(sgList, constants.DefaultDelimiter))
+		}
 
-			var parameterGroups []string
-			for _, pg := range dbInfo.DBParameterGroups {
-				parameterGroups = append(parameterGroups, *pg.DBParameterGroupName)
-			}
-			tmp.ParameterGroup = aws.String(strings.Join(parameterGroups, constants.DefaultDelimiter))
+		tmp.Tags = resource.Tags{}
+		for _, tag := range dbInfo.TagList {
+			tmp.Tags[aws.ToString(tag.Key)] = aws.ToString(tag.Value)
+		}
 
-			var optionGroups []string
-			for _, og := range dbInfo.OptionGroupMemberships {
-				optionGroups = append(optionGroups, *og.OptionGroupName)
-			}
-			tmp.OptionGroup = aws.String(strings.Join(optionGroups, constants.DefaultDelimiter))
+		var sgList []string
+		for _, vpcSgID := range dbInfo.VpcSecurityGroups {
+			sgList = append(sgList, *vpcSgID.VpcSecurityGroupId)
+		}
+		tmp.SecurityGroup = aws.String(strings.Join(sgList, constants.DefaultDelimiter))
 
-			logrus.Debugf("Add new rds instance: %s / %s", *tmp.RDSIdentifier, *tmp.Role)
+		var parameterGroups []string
+		for _, pg := range dbInfo.DBParameterGroups {
+			parameterGroups = append(parameterGroups, *pg.DBParameterGroupName)
+		}
+		tmp.ParameterGroup = aws.String(strings.Join(parameterGroups, constants.DefaultDelimiter))
 
-			ch <- &tmp
+		var optionGroups []string
+		for _, og := range dbInfo.OptionGroupMemberships {
+			optionGroups = append(optionGroups, *og.OptionGroupName)
 		}
-	}
+		tmp.OptionGroup = aws.String(strings.Join(optionGroups, constants.DefaultDelimiter))
 
-	logrus.Debugf("RDS clusters found: %d", len(clusters))
-	for _, cluster := range clusters {
-		wg.Add(1)
-		go f(cluster, input)
+		logrus.Debugf("Add new rds instance: %s / %s", *tmp.RDSIdentifier, *tmp.Role)
+		result = append(result, tmp)
 	}
 
-	wg.Wait()
-	close(input)
-
-	result = <-output
 	logrus.Debugf("total valid RDS data count: %d", len(result))
 
 	return result, nil
@@ -156,24 +174,39 @@ func (r *RDSClient) Scan() ([]resource.Resource, error) {
 
 // GetRDSClusterList returns all DB clusters list in the account
 func (r *RDSClient) GetRDSClusterList() ([]types.DBCluster, error) {
-	result, err := r.Client.DescribeDBClusters(context.TODO(), &rds.DescribeDBClustersInput{})
+	return r.GetDBClusters(nil, nil)
+}
+
+// GetDBClusters returns all DB clusters in the region
+func (r *RDSClient) GetDBClusters(original []types.DBCluster, marker *string) ([]types.DBCluster, error) {
+	result, err := r.Client.DescribeDBClusters(context.TODO(), &rds.DescribeDBClustersInput{
+		Marker: marker,
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	return result.DBClusters, nil
+	original = append(original, result.DBClusters...)
+	if result.Marker != nil {
+		return r.GetDBClusters(original, result.Marker)
+	}
+	return original, nil
 }
 
-// GetRDSInfo returns DB instance information
-func (r *RDSClient) GetRDSInfo(identifier string) (*types.DBInstance, error) {
+// GetDBInstances returns all DB instances in the region
+func (r *RDSClient) GetDBInstances(original []types.DBInstance, marker *string) ([]types.DBInstance, error) {
 	result, err := r.Client.DescribeDBInstances(context.TODO(), &rds.DescribeDBInstancesInput{
-		DBInstanceIdentifier: aws.String(identifier),
+		Marker: marker,
 	})
 	if err != nil {
 		return nil, err
 	}
 
-	return &result.DBInstances[0], nil
+	original = append(original, result.DBInstances...)
+	if result.Marker != nil {
+		return r.GetDBInstances(original, result.Marker)
+	}
+	return original, nil
 }
 
 // SetAlias sets alias
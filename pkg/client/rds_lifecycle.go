@@ -0,0 +1,120 @@
+/*
+Copyright 2020 The redhawk Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// rdsEndOfSupport is the end of standard support of RDS engines by major version.
+// Dates are from the release calendars of Amazon RDS and Aurora, and should be updated when AWS announces new dates.
+// Engines and versions which are not in the table are not checked
+var rdsEndOfSupport = map[string]map[string]string{
+	"mysql": {
+		"5.5": "2020-01-09",
+		"5.6": "2022-03-01",
+		"5.7": "2024-02-29",
+		"8.0": "2026-07-31",
+	},
+	"mariadb": {
+		"10.0": "2020-05-18",
+		"10.1": "2020-10-17",
+		"10.2": "2022-10-15",
+		"10.3": "2023-10-23",
+		"10.4": "2024-06-18",
+		"10.5": "2025-06-24",
+	},
+	"postgres": {
+		"9.5": "2021-03-31",
+		"9.6": "2022-04-26",
+		"10":  "2023-04-17",
+		"11":  "2024-02-29",
+		"12":  "2025-02-28",
+		"13":  "2026-02-28",
+		"14":  "2027-02-28",
+		"15":  "2028-02-29",
+		"16":  "2029-02-28",
+	},
+	"aurora-mysql": {
+		"1": "2023-02-28",
+		"2": "2024-10-31",
+	},
+	"aurora-postgresql": {
+		"9.6": "2022-01-31",
+		"10":  "2023-01-31",
+		"11":  "2024-02-29",
+		"12":  "2025-02-28",
+		"13":  "2026-02-28",
+	},
+}
+
+// RDSEndOfSupport returns the end of standard support of the engine version, or nil if it is unknown
+func RDSEndOfSupport(engine, version string) *time.Time {
+	major := rdsMajorVersion(engine, version)
+	date, ok := rdsEndOfSupport[rdsLifecycleEngine(engine)][major]
+	if !ok {
+		return nil
+	}
+
+	t, err := time.Parse("2006-01-02", date)
+	if err != nil {
+		return nil
+	}
+	return &t
+}
+
+// rdsLifecycleEngine returns the engine name of the lifecycle table. `aurora` is Aurora MySQL 1
+func rdsLifecycleEngine(engine string) string {
+	if engine == "aurora" {
+		return "aurora-mysql"
+	}
+	return engine
+}
+
+// rdsMajorVersion returns the major version of engine version.
+// For example, 5.7 of mysql 5.7.44, 11 of postgres 11.22, 9.6 of postgres 9.6.24 and 2 of aurora-mysql 5.7.mysql_aurora.2.11.2
+func rdsMajorVersion(engine, version string) string {
+	switch engine {
+	case "aurora":
+		return "1"
+	case "aurora-mysql":
+		if i := strings.Index(version, "mysql_aurora."); i >= 0 {
+			return strings.Split(version[i+len("mysql_aurora."):], ".")[0]
+		}
+		if strings.HasPrefix(version, "5.6") {
+			return "1"
+		}
+		if strings.HasPrefix(version, "5.7") {
+			return "2"
+		}
+		return version
+	}
+
+	parts := strings.Split(version, ".")
+	if strings.HasPrefix(engine, "postgres") || engine == "aurora-postgresql" {
+		if major, err := strconv.Atoi(parts[0]); err == nil && major >= 10 {
+			return parts[0]
+		}
+	}
+
+	if len(parts) < 2 {
+		return version
+	}
+	return parts[0] + "." + parts[1]
+}
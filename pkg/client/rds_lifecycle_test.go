@@ -0,0 +1,51 @@
+/*
+Copyright 2020 The redhawk Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"testing"
+)
+
+func TestRDSEndOfSupport(t *testing.T) {
+	tests := []struct {
+		engine   string
+		version  string
+		expected string
+	}{
+		{"mysql", "5.7.44", "2024-02-29"},
+		{"postgres", "9.6.24", "2022-04-26"},
+		{"postgres", "11.22", "2024-02-29"},
+		{"aurora-mysql", "5.7.mysql_aurora.2.11.2", "2024-10-31"},
+		{"aurora", "5.6.10a", "2023-02-28"},
+		{"aurora-mysql", "8.0.mysql_aurora.3.04.0", ""},
+		{"sqlserver-se", "15.00.4322.2.v1", ""},
+	}
+
+	for _, test := range tests {
+		actual := RDSEndOfSupport(test.engine, test.version)
+		if len(test.expected) == 0 {
+			if actual != nil {
+				t.Errorf("%s %s: expected unknown, got %s", test.engine, test.version, actual)
+			}
+			continue
+		}
+
+		if actual == nil || actual.Format("2006-01-02") != test.expected {
+			t.Errorf("%s %s: expected %s, got %v", test.engine, test.version, test.expected, actual)
+		}
+	}
+}
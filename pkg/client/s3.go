@@ -19,12 +19,16 @@ package client
 import (
 	"context"
 	"encoding/base64"
-	"fmt"
+	"errors"
+	"strings"
 	"sync"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
 	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/aws/aws-sdk-go-v2/service/s3control"
+	controltypes "github.com/aws/aws-sdk-go-v2/service/s3control/types"
+	"github.com/aws/smithy-go"
 	"github.com/sirupsen/logrus"
 
 	"github.com/DevopsArtFactory/redhawk/pkg/constants"
@@ -34,19 +38,13 @@ import (
 type S3Client struct {
 	Resource string
 	Client   *s3.Client
+	Control  *s3control.Client
+	Config   aws.Config
+	Region   string
 	Alias    *string
-}
-
-type BucketPolicy struct {
-	Version   string      `json:"Version"`
-	Statement []Statement `json:"Statement"`
-}
 
-type Statement struct {
-	Effect    string      `json:"Effect"`
-	Principal interface{} `json:"Principal"`
-	Action    interface{} `json:"Action"`
-	Resource  interface{} `json:"Resource"`
+	// RegionOnly skips buckets of other regions
+	RegionOnly bool
 }
 
 // GetResourceName returns resource name of client
@@ -59,6 +57,9 @@ func NewS3Client(cfg aws.Config, helper Helper) (Client, error) {
 	return &S3Client{
 		Resource: constants.S3ResourceName,
 		Client:   GetS3ClientFn(cfg),
+		Control:  s3control.NewFromConfig(cfg),
+		Config:   cfg,
+		Region:   helper.Region,
 	}, nil
 }
 
@@ -83,6 +84,29 @@ func (s *S3Client) Scan() ([]resource.Resource, error) {
 		return nil, nil
 	}
 
+	sts, err := NewSTSClient(s.Config)
+	if err != nil {
+		return nil, err
+	}
+
+	accountID, err := sts.GetAccountID()
+	if err != nil {
+		return nil, err
+	}
+
+	// Access of buckets is unknown without account level Block Public Access
+	accountBPA, accountBPAErr := s.GetAccountPublicAccessBlock(*accountID)
+	if accountBPAErr != nil {
+		logrus.Warnf("cannot get block public access of account %s: %s", *accountID, accountBPAErr.Error())
+	}
+
+	// Buckets of trails are not marked if trails cannot be read
+	trail := CloudTrailClient{Client: GetCloudTrailClientFn(s.Config)}
+	trailBuckets, err := trail.GetTrailBuckets()
+	if err != nil {
+		logrus.Debugf("cannot get buckets of trails: %s", err.Error())
+	}
+
 	input := make(chan *resource.S3Resource)
 	output := make(chan []resource.Resource)
 	defer close(output)
@@ -110,37 +134,119 @@ func (s *S3Client) Scan() ([]resource.Resource, error) {
 			return
 		}
 
-		if location == nil {
-			location = aws.String(constants.DefaultRegion)
+		// Buckets are scanned in their own region if the client is scoped to the region
+		if s.RegionOnly && *location != s.Region {
+			ch <- nil
+			return
 		}
 		tmp.Region = location
 
+		tmp.Bucket = bucket.Name
+		tmp.Created = bucket.CreationDate
+		if trailBuckets != nil {
+			tmp.CloudTrailBucket = aws.Bool(trailBuckets[*bucket.Name])
+		}
+
+		// Fields which cannot be read are left empty, so the bucket is still reported
 		logging, err := s.GetBucketLogging(*bucket.Name)
 		if err != nil {
-			ch <- nil
-			return
+			logrus.Warnf("cannot get logging of bucket %s: %s", *bucket.Name, err.Error())
+		} else {
+			tmp.LoggingEnabled = aws.Bool(logging != nil)
+			if logging != nil {
+				tmp.LoggingBucket = logging.TargetBucket
+			}
 		}
 
-		tmp.LoggingEnabled = aws.Bool(false)
-		if logging != nil {
-			tmp.LoggingEnabled = aws.Bool(true)
-			tmp.LoggingBucket = logging.TargetBucket
+		if accountBPAErr == nil {
+			tmp.AccountBlockPublicAccess = aws.Bool(accountBPA.All())
+		}
+		bucketBPA, bpaErr := s.GetBucketPublicAccessBlock(*bucket.Name)
+		if bpaErr != nil {
+			logrus.Warnf("cannot get block public access of bucket %s: %s", *bucket.Name, bpaErr.Error())
+		} else {
+			tmp.BucketBlockPublicAccess = aws.Bool(bucketBPA.All())
 		}
 
-		tmp.Bucket = bucket.Name
-		tmp.Created = bucket.CreationDate
+		grants, aclErr := s.GetBucketACLGrants(*bucket.Name)
+		if aclErr != nil {
+			logrus.Warnf("cannot get acl of bucket %s: %s", *bucket.Name, aclErr.Error())
+		}
 
-		policy, err := s.GetBucketPolicy(*bucket.Name)
-		if err != nil {
-			tmp.Policy = nil
-		} else {
+		aclGrants := PublicACLGrants(grants)
+		if len(aclGrants) > 0 {
+			tmp.PublicACLGrants = aws.String(strings.Join(aclGrants, constants.DefaultDelimiter))
+		}
+
+		// Fields from the policy are unknown if the policy cannot be read or parsed
+		var analysis BucketPolicyAnalysis
+		policy, policyErr := s.GetBucketPolicy(*bucket.Name)
+		if policyErr != nil {
+			logrus.Warnf("cannot get policy of bucket %s: %s", *bucket.Name, policyErr.Error())
+		} else if policy != nil {
 			logrus.Tracef("Bucket policy found: %s", *tmp.Bucket)
 			// base64 encoding
 			base64Policy := base64.StdEncoding.EncodeToString([]byte(*policy))
 
 			logrus.Tracef("Policy is base64 encoded: %s", base64Policy)
 			tmp.Policy = &base64Policy
+
+			var pd *PolicyDocument
+			pd, policyErr = ParsePolicyDocument(*policy)
+			if policyErr != nil {
+				logrus.Errorf("bucket policy cannot be parsed: %s / %s", *tmp.Bucket, policyErr.Error())
+			} else {
+				analysis = AnalyzeBucketPolicy(pd, *accountID)
+			}
+		}
+
+		if policyErr == nil {
+			tmp.PublicPolicy = aws.Bool(analysis.Public)
+			tmp.TLSEnforced = aws.Bool(analysis.TLSEnforced)
+			if len(analysis.CrossAccounts) > 0 {
+				tmp.CrossAccounts = aws.String(strings.Join(analysis.CrossAccounts, constants.DefaultDelimiter))
+			}
+		}
+
+		// Access is unknown without any of Block Public Access, ACL and policy of the bucket
+		if accountBPAErr == nil && bpaErr == nil && aclErr == nil && policyErr == nil {
+			tmp.Access = aws.String(ClassifyBucketAccess(accountBPA.Merge(bucketBPA), analysis, aclGrants))
+		}
+
+		encryption, kmsKeyID, err := s.GetBucketEncryption(*bucket.Name)
+		if err != nil {
+			logrus.Warnf("cannot get encryption of bucket %s: %s", *bucket.Name, err.Error())
+		} else {
+			tmp.Encryption = aws.String(encryption)
+			tmp.KmsKeyID = kmsKeyID
+		}
+
+		versioning, err := s.Client.GetBucketVersioning(context.TODO(), &s3.GetBucketVersioningInput{
+			Bucket: bucket.Name,
+		})
+		if err != nil {
+			logrus.Warnf("cannot get versioning of bucket %s: %s", *bucket.Name, err.Error())
+		} else {
+			tmp.Versioning = aws.String(string(versioning.Status))
+			if len(versioning.Status) == 0 {
+				tmp.Versioning = aws.String("Disabled")
+			}
+			tmp.MFADelete = aws.Bool(versioning.MFADelete == types.MFADeleteStatusEnabled)
+		}
+
+		objectLock, err := s.GetObjectLockEnabled(*bucket.Name)
+		if err != nil {
+			logrus.Warnf("cannot get object lock of bucket %s: %s", *bucket.Name, err.Error())
+		} else {
+			tmp.ObjectLock = aws.Bool(objectLock)
+		}
+
+		tags, err := s.GetBucketTags(*bucket.Name)
+		if err != nil {
+			logrus.Warnf("cannot get tags of bucket %s: %s", *bucket.Name, err.Error())
+			tmp.TagsUnknown = aws.Bool(true)
 		}
+		tmp.Tags = tags
 
 		logrus.Tracef("new bucket is added: %s / %s", *tmp.Bucket, *tmp.Region)
 
@@ -160,13 +266,14 @@ func (s *S3Client) Scan() ([]resource.Resource, error) {
 	logrus.Debugf("total valid s3 data count: %d", len(result))
 
 	if len(result) == 0 {
-		return nil, fmt.Errorf("no bucket exists in the region")
+		logrus.Debugf("no bucket exists in the region: %s", s.Region)
+		return nil, nil
 	}
 
 	return result, nil
 }
 
-// GetSGList returns all security group list in the account
+// GetBucketList returns all buckets in the account
 func (s *S3Client) GetBucketList() ([]types.Bucket, error) {
 	result, err := s.Client.ListBuckets(context.TODO(), &s3.ListBucketsInput{})
 	if err != nil {
@@ -185,15 +292,26 @@ func (s *S3Client) GetBucketLocation(bucket string) (*string, error) {
 		return nil, err
 	}
 
+	// Buckets in us-east-1 have no location constraint, and EU is the legacy name of eu-west-1
+	switch result.LocationConstraint {
+	case "":
+		return aws.String(constants.DefaultRegion), nil
+	case types.BucketLocationConstraintEu:
+		return aws.String("eu-west-1"), nil
+	}
+
 	return aws.String(string(result.LocationConstraint)), nil
 }
 
-// GetBucketPolicy returns a bucket policy
+// GetBucketPolicy returns a bucket policy. nil is returned if the bucket has no policy
 func (s *S3Client) GetBucketPolicy(bucket string) (*string, error) {
 	result, err := s.Client.GetBucketPolicy(context.TODO(), &s3.GetBucketPolicyInput{
 		Bucket: aws.String(bucket),
 	})
 	if err != nil {
+		if isErrorCode(err, "NoSuchBucketPolicy") {
+			return nil, nil
+		}
 		return nil, err
 	}
 
@@ -212,7 +330,148 @@ func (s *S3Client) GetBucketLogging(bucket string) (*types.LoggingEnabled, error
 	return result.LoggingEnabled, nil
 }
 
+// GetAccountPublicAccessBlock returns account level Block Public Access settings
+func (s *S3Client) GetAccountPublicAccessBlock(accountID string) (BlockPublicAccess, error) {
+	result, err := s.Control.GetPublicAccessBlock(context.TODO(), &s3control.GetPublicAccessBlockInput{
+		AccountId: aws.String(accountID),
+	})
+	if err != nil {
+		var notFound *controltypes.NoSuchPublicAccessBlockConfiguration
+		if errors.As(err, &notFound) {
+			return BlockPublicAccess{}, nil
+		}
+		return BlockPublicAccess{}, err
+	}
+
+	c := result.PublicAccessBlockConfiguration
+	if c == nil {
+		return BlockPublicAccess{}, nil
+	}
+
+	return BlockPublicAccess{
+		BlockPublicAcls:       c.BlockPublicAcls,
+		IgnorePublicAcls:      c.IgnorePublicAcls,
+		BlockPublicPolicy:     c.BlockPublicPolicy,
+		RestrictPublicBuckets: c.RestrictPublicBuckets,
+	}, nil
+}
+
+// GetBucketPublicAccessBlock returns bucket level Block Public Access settings
+func (s *S3Client) GetBucketPublicAccessBlock(bucket string) (BlockPublicAccess, error) {
+	result, err := s.Client.GetPublicAccessBlock(context.TODO(), &s3.GetPublicAccessBlockInput{
+		Bucket: aws.String(bucket),
+	})
+	if err != nil {
+		if isErrorCode(err, "NoSuchPublicAccessBlockConfiguration") {
+			return BlockPublicAccess{}, nil
+		}
+		return BlockPublicAccess{}, err
+	}
+
+	c := result.PublicAccessBlockConfiguration
+	if c == nil {
+		return BlockPublicAccess{}, nil
+	}
+
+	return BlockPublicAccess{
+		BlockPublicAcls:       c.BlockPublicAcls,
+		IgnorePublicAcls:      c.IgnorePublicAcls,
+		BlockPublicPolicy:     c.BlockPublicPolicy,
+		RestrictPublicBuckets: c.RestrictPublicBuckets,
+	}, nil
+}
+
+// GetBucketACLGrants returns grants of bucket ACL
+func (s *S3Client) GetBucketACLGrants(bucket string) ([]types.Grant, error) {
+	result, err := s.Client.GetBucketAcl(context.TODO(), &s3.GetBucketAclInput{
+		Bucket: aws.String(bucket),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result.Grants, nil
+}
+
+// GetBucketEncryption returns default encryption and KMS key of bucket
+func (s *S3Client) GetBucketEncryption(bucket string) (string, *string, error) {
+	result, err := s.Client.GetBucketEncryption(context.TODO(), &s3.GetBucketEncryptionInput{
+		Bucket: aws.String(bucket),
+	})
+	if err != nil {
+		if isErrorCode(err, "ServerSideEncryptionConfigurationNotFoundError") {
+			return constants.S3EncryptionNone, nil, nil
+		}
+		return constants.EmptyString, nil, err
+	}
+
+	if result.ServerSideEncryptionConfiguration == nil {
+		return constants.S3EncryptionNone, nil, nil
+	}
+
+	for _, rule := range result.ServerSideEncryptionConfiguration.Rules {
+		d := rule.ApplyServerSideEncryptionByDefault
+		if d == nil {
+			continue
+		}
+
+		if d.SSEAlgorithm == types.ServerSideEncryptionAes256 {
+			return constants.S3EncryptionSSES3, nil, nil
+		}
+		return constants.S3EncryptionSSEKMS, d.KMSMasterKeyID, nil
+	}
+
+	return constants.S3EncryptionNone, nil, nil
+}
+
+// GetObjectLockEnabled checks if object lock is enabled for bucket
+func (s *S3Client) GetObjectLockEnabled(bucket string) (bool, error) {
+	result, err := s.Client.GetObjectLockConfiguration(context.TODO(), &s3.GetObjectLockConfigurationInput{
+		Bucket: aws.String(bucket),
+	})
+	if err != nil {
+		if isErrorCode(err, "ObjectLockConfigurationNotFoundError") {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return result.ObjectLockConfiguration != nil &&
+		result.ObjectLockConfiguration.ObjectLockEnabled == types.ObjectLockEnabledEnabled, nil
+}
+
+// GetBucketTags returns tags of bucket
+func (s *S3Client) GetBucketTags(bucket string) (resource.Tags, error) {
+	result, err := s.Client.GetBucketTagging(context.TODO(), &s3.GetBucketTaggingInput{
+		Bucket: aws.String(bucket),
+	})
+	if err != nil {
+		if isErrorCode(err, "NoSuchTagSet") {
+			return resource.Tags{}, nil
+		}
+		return nil, err
+	}
+
+	tags := resource.Tags{}
+	for _, tag := range result.TagSet {
+		tags[aws.ToString(tag.Key)] = aws.ToString(tag.Value)
+	}
+	return tags, nil
+}
+
+// ScopeToRegion makes the client skip buckets of other regions
+func (s *S3Client) ScopeToRegion() {
+	s.RegionOnly = true
+}
+
 // SetAlias sets alias
 func (s *S3Client) SetAlias(alias *string) {
 	s.Alias = alias
 }
+
+// isErrorCode checks if error is an API error with the code.
+// S3 does not model errors for missing bucket configurations
+func isErrorCode(err error, code string) bool {
+	var apiErr smithy.APIError
+	return errors.As(err, &apiErr) && apiErr.ErrorCode() == code
+}
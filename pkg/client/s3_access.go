@@ -0,0 +1,154 @@
+/*
+Copyright 2020 The redhawk Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+
+	"github.com/DevopsArtFactory/redhawk/pkg/constants"
+	"github.com/DevopsArtFactory/redhawk/pkg/tools"
+)
+
+// BucketPolicyAnalysis is the result of bucket policy analysis
+type BucketPolicyAnalysis struct {
+	Public        bool
+	CrossAccounts []string
+	TLSEnforced   bool
+}
+
+// BlockPublicAccess is the effective Block Public Access setting of a bucket
+type BlockPublicAccess struct {
+	BlockPublicAcls       bool
+	IgnorePublicAcls      bool
+	BlockPublicPolicy     bool
+	RestrictPublicBuckets bool
+}
+
+// Merge applies settings of account and bucket level together
+func (b BlockPublicAccess) Merge(other BlockPublicAccess) BlockPublicAccess {
+	return BlockPublicAccess{
+		BlockPublicAcls:       b.BlockPublicAcls || other.BlockPublicAcls,
+		IgnorePublicAcls:      b.IgnorePublicAcls || other.IgnorePublicAcls,
+		BlockPublicPolicy:     b.BlockPublicPolicy || other.BlockPublicPolicy,
+		RestrictPublicBuckets: b.RestrictPublicBuckets || other.RestrictPublicBuckets,
+	}
+}
+
+// All checks if all settings are enabled
+func (b BlockPublicAccess) All() bool {
+	return b.BlockPublicAcls && b.IgnorePublicAcls && b.BlockPublicPolicy && b.RestrictPublicBuckets
+}
+
+// AnalyzeBucketPolicy finds public and cross-account statements, and TLS enforcement in the bucket policy.
+// Statements for wildcard principal with network or account conditions are not public.
+func AnalyzeBucketPolicy(pd *PolicyDocument, accountID string) BucketPolicyAnalysis {
+	var ret BucketPolicyAnalysis
+	crossAccounts := map[string]bool{}
+
+	for _, statement := range pd.Statement {
+		conditions := map[string][]string{}
+		for _, values := range statement.Condition {
+			for key, value := range values {
+				conditions[strings.ToLower(key)] = append(conditions[strings.ToLower(key)], toStringSlice(value)...)
+			}
+		}
+
+		if statement.Effect == "Deny" {
+			if tools.IsStringInArray("false", conditions["aws:securetransport"]) {
+				ret.TLSEnforced = true
+			}
+			continue
+		}
+
+		if statement.Effect != "Allow" || statement.Principal == nil {
+			continue
+		}
+
+		for _, principal := range toStringSlice(statement.Principal.AWS) {
+			if principal != "*" {
+				if m := accountIDRegex.FindStringSubmatch(principal); m != nil && m[1] != accountID {
+					crossAccounts[m[1]] = true
+				}
+				continue
+			}
+
+			restricted := false
+			for _, key := range constants.RestrictingConditionKeys {
+				if _, ok := conditions[key]; ok {
+					restricted = true
+				}
+			}
+
+			if !restricted {
+				ret.Public = true
+			}
+
+			for _, key := range []string{"aws:principalaccount", "aws:sourceaccount"} {
+				for _, account := range conditions[key] {
+					if account != accountID {
+						crossAccounts[account] = true
+					}
+				}
+			}
+		}
+	}
+
+	for account := range crossAccounts {
+		ret.CrossAccounts = append(ret.CrossAccounts, account)
+	}
+	sort.Strings(ret.CrossAccounts)
+
+	return ret
+}
+
+// PublicACLGrants returns grants of the ACL to everyone or any authenticated AWS user
+func PublicACLGrants(grants []types.Grant) []string {
+	var ret []string
+	for _, g := range grants {
+		if g.Grantee == nil || g.Grantee.Type != types.TypeGroup {
+			continue
+		}
+
+		if group, ok := constants.PublicACLGroups[aws.ToString(g.Grantee.URI)]; ok {
+			ret = append(ret, fmt.Sprintf("%s:%s", group, g.Permission))
+		}
+	}
+	return ret
+}
+
+// ClassifyBucketAccess returns public, cross-account or private.
+// Public policy and ACL are ignored if Block Public Access restricts them.
+func ClassifyBucketAccess(bpa BlockPublicAccess, policy BucketPolicyAnalysis, aclGrants []string) string {
+	if policy.Public && !bpa.RestrictPublicBuckets {
+		return constants.S3AccessPublic
+	}
+
+	if len(aclGrants) > 0 && !bpa.IgnorePublicAcls {
+		return constants.S3AccessPublic
+	}
+
+	if len(policy.CrossAccounts) > 0 {
+		return constants.S3AccessCrossAccount
+	}
+
+	return constants.S3AccessPrivate
+}
@@ -0,0 +1,93 @@
+/*
+Copyright 2020 The redhawk Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+
+	"github.com/DevopsArtFactory/redhawk/pkg/constants"
+)
+
+func TestAnalyzeBucketPolicy(t *testing.T) {
+	document := `{
+		"Version": "2012-10-17",
+		"Statement": [
+			{"Effect": "Allow", "Principal": "*", "Action": "s3:GetObject", "Resource": "arn:aws:s3:::bucket/*"},
+			{"Effect": "Allow", "Principal": "*", "Action": "s3:GetObject", "Resource": "arn:aws:s3:::bucket/*",
+				"Condition": {"StringEquals": {"aws:SourceVpce": "vpce-1"}}},
+			{"Effect": "Allow", "Principal": {"AWS": ["arn:aws:iam::222222222222:root", "111111111111"]}, "Action": "s3:*", "Resource": "*"},
+			{"Effect": "Deny", "Principal": "*", "Action": "s3:*", "Resource": "*",
+				"Condition": {"Bool": {"aws:SecureTransport": "false"}}}
+		]
+	}`
+
+	pd, err := ParsePolicyDocument(document)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	analysis := AnalyzeBucketPolicy(pd, "111111111111")
+	if !analysis.Public {
+		t.Error("expected public policy")
+	}
+
+	if !analysis.TLSEnforced {
+		t.Error("expected TLS to be enforced")
+	}
+
+	if len(analysis.CrossAccounts) != 1 || analysis.CrossAccounts[0] != "222222222222" {
+		t.Errorf("unexpected cross accounts: %v", analysis.CrossAccounts)
+	}
+}
+
+func TestClassifyBucketAccess(t *testing.T) {
+	grants := PublicACLGrants([]types.Grant{
+		{
+			Grantee:    &types.Grantee{Type: types.TypeGroup, URI: aws.String("http://acs.amazonaws.com/groups/global/AllUsers")},
+			Permission: types.PermissionRead,
+		},
+		{
+			Grantee:    &types.Grantee{Type: types.TypeCanonicalUser, ID: aws.String("owner")},
+			Permission: types.PermissionFullControl,
+		},
+	})
+	if len(grants) != 1 || grants[0] != "AllUsers:READ" {
+		t.Fatalf("unexpected public grants: %v", grants)
+	}
+
+	tests := []struct {
+		bpa      BlockPublicAccess
+		policy   BucketPolicyAnalysis
+		grants   []string
+		expected string
+	}{
+		{BlockPublicAccess{}, BucketPolicyAnalysis{Public: true}, nil, constants.S3AccessPublic},
+		{BlockPublicAccess{RestrictPublicBuckets: true}, BucketPolicyAnalysis{Public: true}, nil, constants.S3AccessPrivate},
+		{BlockPublicAccess{}, BucketPolicyAnalysis{}, grants, constants.S3AccessPublic},
+		{BlockPublicAccess{IgnorePublicAcls: true}, BucketPolicyAnalysis{CrossAccounts: []string{"222222222222"}}, grants, constants.S3AccessCrossAccount},
+		{BlockPublicAccess{}, BucketPolicyAnalysis{}, nil, constants.S3AccessPrivate},
+	}
+
+	for i, test := range tests {
+		if got := ClassifyBucketAccess(test.bpa, test.policy, test.grants); got != test.expected {
+			t.Errorf("case %d: expected %s, got %s", i, test.expected, got)
+		}
+	}
+}
@@ -0,0 +1,259 @@
+/*
+Copyright 2020 The redhawk Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"sort"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/aws/aws-sdk-go-v2/service/lambda"
+	lambdatypes "github.com/aws/aws-sdk-go-v2/service/lambda/types"
+	"github.com/sirupsen/logrus"
+
+	"github.com/DevopsArtFactory/redhawk/pkg/constants"
+	"github.com/DevopsArtFactory/redhawk/pkg/resource"
+	"github.com/DevopsArtFactory/redhawk/pkg/secrets"
+)
+
+// SecretClient finds secrets in user data of instances and launch templates, and environment variables of Lambda functions
+type SecretClient struct {
+	Resource string
+	EC2      *ec2.Client
+	Lambda   *lambda.Client
+	Region   string
+	Alias    *string
+}
+
+// GetResourceName returns resource name of client
+func (s *SecretClient) GetResourceName() string {
+	return s.Resource
+}
+
+// NewSecretClient creates a SecretClient
+func NewSecretClient(cfg aws.Config, helper Helper) (Client, error) {
+	return &SecretClient{
+		Resource: constants.SecretResourceName,
+		EC2:      GetEC2ClientFn(cfg),
+		Lambda:   GetLambdaClientFn(cfg),
+		Region:   helper.Region,
+	}, nil
+}
+
+// GetLambdaClientFn creates lambda client
+func GetLambdaClientFn(cfg aws.Config) *lambda.Client {
+	return lambda.NewFromConfig(cfg)
+}
+
+// Scan fetches user data and environment variables, and returns secrets found in them. Secrets are redacted
+func (s *SecretClient) Scan() ([]resource.Resource, error) {
+	var result []resource.Resource
+
+	logrus.Debug("Start scanning secrets in user data of instances")
+	found, err := s.scanInstanceUserData()
+	if err != nil {
+		return nil, err
+	}
+	result = append(result, found...)
+
+	logrus.Debug("Start scanning secrets in user data of launch templates")
+	found, err = s.scanLaunchTemplateUserData()
+	if err != nil {
+		return nil, err
+	}
+	result = append(result, found...)
+
+	logrus.Debug("Start scanning secrets in environment variables of lambda functions")
+	found, err = s.scanLambdaEnvironment()
+	if err != nil {
+		return nil, err
+	}
+	result = append(result, found...)
+
+	logrus.Debugf("total secret count: %d", len(result))
+
+	return result, nil
+}
+
+// scanInstanceUserData finds secrets in user data of instances which are not terminated
+func (s *SecretClient) scanInstanceUserData() ([]resource.Resource, error) {
+	ec2Client := EC2Client{Client: s.EC2}
+	reservations, err := ec2Client.GetEC2Instances(nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []resource.Resource
+	for _, reservation := range reservations {
+		for _, instance := range reservation.Instances {
+			if instance.State != nil && instance.State.Name == ec2types.InstanceStateNameTerminated {
+				continue
+			}
+
+			attribute, err := s.EC2.DescribeInstanceAttribute(context.TODO(), &ec2.DescribeInstanceAttributeInput{
+				Attribute:  ec2types.InstanceAttributeNameUserData,
+				InstanceId: instance.InstanceId,
+			})
+			if err != nil {
+				return nil, err
+			}
+
+			if attribute.UserData == nil {
+				continue
+			}
+
+			for _, m := range detectUserData(aws.ToString(attribute.UserData.Value)) {
+				result = append(result, s.newSecret(constants.SecretSourceUserData, aws.ToString(instance.InstanceId), fmt.Sprintf("line %d", m.Line), m))
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// scanLaunchTemplateUserData finds secrets in user data of the default and latest versions of launch templates
+func (s *SecretClient) scanLaunchTemplateUserData() ([]resource.Resource, error) {
+	templates, err := s.GetLaunchTemplates(nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []resource.Resource
+	for _, template := range templates {
+		versions, err := s.EC2.DescribeLaunchTemplateVersions(context.TODO(), &ec2.DescribeLaunchTemplateVersionsInput{
+			LaunchTemplateId: template.LaunchTemplateId,
+			Versions:         []string{"$Default", "$Latest"},
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		checked := map[int64]bool{}
+		for _, version := range versions.LaunchTemplateVersions {
+			number := aws.ToInt64(version.VersionNumber)
+			if checked[number] || version.LaunchTemplateData == nil {
+				continue
+			}
+			checked[number] = true
+
+			id := fmt.Sprintf("%s(%s)", aws.ToString(template.LaunchTemplateId), aws.ToString(template.LaunchTemplateName))
+			for _, m := range detectUserData(aws.ToString(version.LaunchTemplateData.UserData)) {
+				result = append(result, s.newSecret(constants.SecretSourceLaunchTemplateUserData, id, fmt.Sprintf("version %d line %d", number, m.Line), m))
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// scanLambdaEnvironment finds secrets in environment variables of lambda functions
+func (s *SecretClient) scanLambdaEnvironment() ([]resource.Resource, error) {
+	functions, err := s.GetFunctions(nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []resource.Resource
+	for _, function := range functions {
+		if function.Environment == nil {
+			continue
+		}
+
+		var keys []string
+		for k := range function.Environment.Variables {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		for _, k := range keys {
+			for _, m := range secrets.Detect([]byte(fmt.Sprintf("%s=%s", k, function.Environment.Variables[k]))) {
+				result = append(result, s.newSecret(constants.SecretSourceLambdaEnvironment, aws.ToString(function.FunctionName), k, m))
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// newSecret creates a secret resource with the redacted secret
+func (s *SecretClient) newSecret(source, id, location string, m secrets.Match) resource.SecretResource {
+	return resource.SecretResource{
+		ResourceType: aws.String(constants.SecretResourceName),
+		Source:       aws.String(source),
+		ResourceID:   aws.String(id),
+		Location:     aws.String(location),
+		Detector:     aws.String(m.Detector),
+		Secret:       aws.String(m.Redacted),
+		Region:       aws.String(s.Region),
+	}
+}
+
+// detectUserData decodes base64 user data and returns secrets in it
+func detectUserData(encoded string) []secrets.Match {
+	if len(encoded) == 0 {
+		return nil
+	}
+
+	decoded, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		logrus.Debugf("user data is not base64 encoded: %v", err)
+		decoded = []byte(encoded)
+	}
+
+	return secrets.Detect(decoded)
+}
+
+// GetLaunchTemplates returns all launch templates in the region
+func (s *SecretClient) GetLaunchTemplates(original []ec2types.LaunchTemplate, nextToken *string) ([]ec2types.LaunchTemplate, error) {
+	result, err := s.EC2.DescribeLaunchTemplates(context.TODO(), &ec2.DescribeLaunchTemplatesInput{
+		NextToken: nextToken,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	original = append(original, result.LaunchTemplates...)
+	if result.NextToken != nil {
+		return s.GetLaunchTemplates(original, result.NextToken)
+	}
+	return original, nil
+}
+
+// GetFunctions returns all lambda functions in the region
+func (s *SecretClient) GetFunctions(original []lambdatypes.FunctionConfiguration, marker *string) ([]lambdatypes.FunctionConfiguration, error) {
+	result, err := s.Lambda.ListFunctions(context.TODO(), &lambda.ListFunctionsInput{
+		Marker: marker,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	original = append(original, result.Functions...)
+	if result.NextMarker != nil {
+		return s.GetFunctions(original, result.NextMarker)
+	}
+	return original, nil
+}
+
+// SetAlias sets alias
+func (s *SecretClient) SetAlias(alias *string) {
+	s.Alias = alias
+}
@@ -18,11 +18,16 @@ package client
 
 import (
 	"context"
+	"sync"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials/stscreds"
 )
 
+// assumedCredentials keeps credentials of assumed roles so that a role is assumed once per run
+var assumedCredentials sync.Map
+
 // GetAwsSession creates new session for AWS
 func GetAwsSession(region string) aws.Config {
 	var optFunc config.LoadOptionsFunc
@@ -34,3 +39,19 @@ func GetAwsSession(region string) aws.Config {
 	)
 	return cfg
 }
+
+// GetAwsSessionWithRole creates new session for AWS with credentials of the assumed role.
+// Default credentials are used if role is empty
+func GetAwsSessionWithRole(region, roleArn string) aws.Config {
+	cfg := GetAwsSession(region)
+	if len(roleArn) == 0 {
+		return cfg
+	}
+
+	provider, _ := assumedCredentials.LoadOrStore(roleArn, aws.NewCredentialsCache(
+		stscreds.NewAssumeRoleProvider(GetSTSClientFn(cfg), roleArn),
+	))
+	cfg.Credentials = provider.(*aws.CredentialsCache)
+
+	return cfg
+}
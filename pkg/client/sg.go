@@ -18,6 +18,8 @@ package client
 
 import (
 	"context"
+	"sort"
+	"strings"
 	"sync"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
@@ -53,7 +55,7 @@ func (s *SGClient) Scan() ([]resource.Resource, error) {
 	var wg sync.WaitGroup
 	var result []resource.Resource
 
-	securityGroups, err := s.GetSGList()
+	securityGroups, err := s.GetSGList(nil, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -63,6 +65,13 @@ func (s *SGClient) Scan() ([]resource.Resource, error) {
 		return nil, nil
 	}
 
+	networkInterfaces, err := s.GetNetworkInterfaces(nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	attachments := SGAttachments(networkInterfaces)
+	references := SGReferences(securityGroups)
+
 	input := make(chan resource.SGResource)
 	output := make(chan []resource.Resource)
 	defer close(output)
@@ -87,21 +96,46 @@ func (s *SGClient) Scan() ([]resource.Resource, error) {
 		tmp.VpcID = sg.VpcId
 		tmp.Owner = sg.OwnerId
 		tmp.Description = sg.Description
-		inboundCount := 0
+		tmp.Tags = ec2Tags(sg.Tags)
+		inboundCount, inboundTotal := 0, 0
 		for _, in := range sg.IpPermissions {
 			inboundCount += len(in.IpRanges)
 			inboundCount += len(in.UserIdGroupPairs)
+			inboundTotal += len(in.Ipv6Ranges)
+			inboundTotal += len(in.PrefixListIds)
 		}
 
-		outboundCount := 0
+		outboundCount, outboundTotal := 0, 0
 		for _, out := range sg.IpPermissionsEgress {
 			outboundCount += len(out.IpRanges)
 			outboundCount += len(out.UserIdGroupPairs)
+			outboundTotal += len(out.Ipv6Ranges)
+			outboundTotal += len(out.PrefixListIds)
 		}
 
 		tmp.InboundCount = aws.Int(inboundCount)
 		tmp.OutboundCount = aws.Int(outboundCount)
 
+		// Total counts include IPv6 ranges and prefix lists as well
+		tmp.InboundTotalCount = aws.Int(inboundCount + inboundTotal)
+		tmp.OutboundTotalCount = aws.Int(outboundCount + outboundTotal)
+		tmp.Default = aws.Bool(aws.ToString(sg.GroupName) == constants.DefaultSGName)
+
+		attached := attachments[*sg.GroupId]
+		referencedBy := references[*sg.GroupId]
+		tmp.AttachmentCount = aws.Int(len(attached))
+		tmp.AttachedTo = aws.String(strings.Join(uniqueSorted(attached), constants.DefaultDelimiter))
+		tmp.ReferencedBy = aws.String(strings.Join(referencedBy, constants.DefaultDelimiter))
+
+		switch {
+		case len(attached) > 0:
+			tmp.Usage = aws.String(constants.SGUsageInUse)
+		case len(referencedBy) > 0:
+			tmp.Usage = aws.String(constants.SGUsageReferencedOnly)
+		default:
+			tmp.Usage = aws.String(constants.SGUsageUnused)
+		}
+
 		ch <- tmp
 	}
 
@@ -121,13 +155,109 @@ func (s *SGClient) Scan() ([]resource.Resource, error) {
 }
 
 // GetSGList returns all security group list in the account
-func (s *SGClient) GetSGList() ([]types.SecurityGroup, error) {
-	result, err := s.Client.DescribeSecurityGroups(context.TODO(), &ec2.DescribeSecurityGroupsInput{})
+func (s *SGClient) GetSGList(original []types.SecurityGroup, nextToken *string) ([]types.SecurityGroup, error) {
+	result, err := s.Client.DescribeSecurityGroups(context.TODO(), &ec2.DescribeSecurityGroupsInput{
+		NextToken: nextToken,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	original = append(original, result.SecurityGroups...)
+	if result.NextToken != nil {
+		return s.GetSGList(original, result.NextToken)
+	}
+	return original, nil
+}
+
+// GetNetworkInterfaces returns all network interfaces in the region
+func (s *SGClient) GetNetworkInterfaces(original []types.NetworkInterface, nextToken *string) ([]types.NetworkInterface, error) {
+	result, err := s.Client.DescribeNetworkInterfaces(context.TODO(), &ec2.DescribeNetworkInterfacesInput{
+		NextToken: nextToken,
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	return result.SecurityGroups, nil
+	original = append(original, result.NetworkInterfaces...)
+	if result.NextToken != nil {
+		return s.GetNetworkInterfaces(original, result.NextToken)
+	}
+	return original, nil
+}
+
+// SGAttachments returns owners of network interfaces for each security group
+func SGAttachments(networkInterfaces []types.NetworkInterface) map[string][]string {
+	ret := map[string][]string{}
+	for _, eni := range networkInterfaces {
+		owner := networkInterfaceOwner(eni)
+		for _, g := range eni.Groups {
+			if g.GroupId != nil {
+				ret[*g.GroupId] = append(ret[*g.GroupId], owner)
+			}
+		}
+	}
+	return ret
+}
+
+// SGReferences returns security groups whose rules refer to each security group.
+// References to itself are not counted.
+func SGReferences(securityGroups []types.SecurityGroup) map[string][]string {
+	set := map[string]map[string]bool{}
+	for _, sg := range securityGroups {
+		permissions := append(append([]types.IpPermission{}, sg.IpPermissions...), sg.IpPermissionsEgress...)
+		for _, p := range permissions {
+			for _, pair := range p.UserIdGroupPairs {
+				if pair.GroupId == nil || *pair.GroupId == aws.ToString(sg.GroupId) {
+					continue
+				}
+
+				if _, ok := set[*pair.GroupId]; !ok {
+					set[*pair.GroupId] = map[string]bool{}
+				}
+				set[*pair.GroupId][*sg.GroupId] = true
+			}
+		}
+	}
+
+	ret := map[string][]string{}
+	for id, refs := range set {
+		for ref := range refs {
+			ret[id] = append(ret[id], ref)
+		}
+		sort.Strings(ret[id])
+	}
+	return ret
+}
+
+// networkInterfaceOwner guesses the service which uses the network interface
+func networkInterfaceOwner(eni types.NetworkInterface) string {
+	if eni.Attachment != nil && eni.Attachment.InstanceId != nil {
+		return constants.EC2ResourceName
+	}
+
+	description := aws.ToString(eni.Description)
+	for prefix, owner := range constants.NetworkInterfaceOwners {
+		if strings.HasPrefix(description, prefix) {
+			return owner
+		}
+	}
+
+	return string(eni.InterfaceType)
+}
+
+// uniqueSorted removes duplicated values and sorts them
+func uniqueSorted(values []string) []string {
+	set := map[string]bool{}
+	var ret []string
+	for _, v := range values {
+		if !set[v] {
+			set[v] = true
+			ret = append(ret, v)
+		}
+	}
+	sort.Strings(ret)
+	return ret
 }
 
 // SetAlias sets alias
@@ -0,0 +1,204 @@
+/*
+Copyright 2020 The redhawk Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/sirupsen/logrus"
+
+	"github.com/DevopsArtFactory/redhawk/pkg/constants"
+	"github.com/DevopsArtFactory/redhawk/pkg/resource"
+	"github.com/DevopsArtFactory/redhawk/pkg/tools"
+)
+
+type SGRuleClient struct {
+	Resource string
+	SG       *SGClient
+	Alias    *string
+}
+
+// GetResourceName returns resource name of client
+func (s SGRuleClient) GetResourceName() string {
+	return s.Resource
+}
+
+// NewSGRuleClient creates a SGRuleClient
+func NewSGRuleClient(cfg aws.Config, _ Helper) (Client, error) {
+	return &SGRuleClient{
+		Resource: constants.SGRuleResourceName,
+		SG: &SGClient{
+			Resource: constants.SGResourceName,
+			Client:   GetEC2ClientFn(cfg),
+		},
+	}, nil
+}
+
+// Scan scans all data
+func (s *SGRuleClient) Scan() ([]resource.Resource, error) {
+	var result []resource.Resource
+
+	securityGroups, err := s.SG.GetSGList(nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(securityGroups) == 0 {
+		logrus.Debug("no security group found")
+		return nil, nil
+	}
+
+	for _, sg := range securityGroups {
+		for _, r := range SGRules(sg) {
+			result = append(result, r)
+		}
+	}
+	logrus.Debugf("total valid security group rule data count: %d", len(result))
+
+	return result, nil
+}
+
+// SetAlias sets alias
+func (s *SGRuleClient) SetAlias(alias *string) {
+	s.Alias = alias
+}
+
+// SGRules makes one row per source or destination of permissions in the security group
+func SGRules(sg types.SecurityGroup) []resource.SGRuleResource {
+	var ret []resource.SGRuleResource
+	for _, p := range sg.IpPermissions {
+		ret = append(ret, permissionRules(sg, p, constants.SGRuleIngress)...)
+	}
+
+	for _, p := range sg.IpPermissionsEgress {
+		ret = append(ret, permissionRules(sg, p, constants.SGRuleEgress)...)
+	}
+
+	return ret
+}
+
+// permissionRules makes rows of a permission
+func permissionRules(sg types.SecurityGroup, p types.IpPermission, direction string) []resource.SGRuleResource {
+	base := resource.SGRuleResource{
+		ResourceType: aws.String(constants.SGRuleResourceName),
+		GroupID:      sg.GroupId,
+		GroupName:    sg.GroupName,
+		VpcID:        sg.VpcId,
+		Direction:    aws.String(direction),
+	}
+
+	protocol := aws.ToString(p.IpProtocol)
+	from, to := portRange(p)
+	if protocol == "-1" {
+		protocol = constants.SGRuleAllProtocols
+	}
+	base.Protocol = aws.String(protocol)
+	base.PortRange = aws.String(formatPortRange(protocol, from, to))
+	if from >= 0 {
+		base.FromPort = aws.Int(from)
+		base.ToPort = aws.Int(to)
+	}
+
+	var ret []resource.SGRuleResource
+	add := func(sourceType string, source, description *string) {
+		tmp := base
+		tmp.SourceType = aws.String(sourceType)
+		tmp.Source = source
+		tmp.Description = description
+
+		worldOpen := (sourceType == constants.SGRuleSourceCIDR || sourceType == constants.SGRuleSourceIPv6) &&
+			tools.IsStringInArray(aws.ToString(source), constants.WorldOpenCIDRs)
+		tmp.WorldOpen = aws.Bool(worldOpen)
+
+		if ports := sensitivePorts(protocol, from, to); len(ports) > 0 {
+			tmp.SensitivePorts = aws.String(ports)
+		}
+
+		ret = append(ret, tmp)
+	}
+
+	for _, r := range p.IpRanges {
+		add(constants.SGRuleSourceCIDR, r.CidrIp, r.Description)
+	}
+
+	for _, r := range p.Ipv6Ranges {
+		add(constants.SGRuleSourceIPv6, r.CidrIpv6, r.Description)
+	}
+
+	for _, r := range p.PrefixListIds {
+		add(constants.SGRuleSourcePrefixList, r.PrefixListId, r.Description)
+	}
+
+	for _, r := range p.UserIdGroupPairs {
+		source := r.GroupId
+		if r.UserId != nil && sg.OwnerId != nil && *r.UserId != *sg.OwnerId {
+			source = aws.String(fmt.Sprintf("%s/%s", *r.UserId, aws.ToString(r.GroupId)))
+		}
+		add(constants.SGRuleSourceSecurityGroup, source, r.Description)
+	}
+
+	return ret
+}
+
+// portRange returns port range of permission. All ports are -1
+func portRange(p types.IpPermission) (int, int) {
+	if p.FromPort == nil || p.ToPort == nil || aws.ToString(p.IpProtocol) == "-1" {
+		return -1, -1
+	}
+	return int(*p.FromPort), int(*p.ToPort)
+}
+
+// formatPortRange returns port range for printing
+func formatPortRange(protocol string, from, to int) string {
+	switch {
+	case protocol == constants.SGRuleAllProtocols || from < 0:
+		return constants.SGRuleAllProtocols
+	case protocol == "icmp" || protocol == "icmpv6" || protocol == "1" || protocol == "58":
+		return fmt.Sprintf("type %d/code %d", from, to)
+	case from == 0 && to == 65535:
+		return constants.SGRuleAllProtocols
+	case from == to:
+		return fmt.Sprintf("%d", from)
+	}
+	return fmt.Sprintf("%d-%d", from, to)
+}
+
+// sensitivePorts returns sensitive ports in the port range
+func sensitivePorts(protocol string, from, to int) string {
+	if protocol != constants.SGRuleAllProtocols && protocol != "tcp" && protocol != "6" {
+		return constants.EmptyString
+	}
+
+	var ports []int
+	for port := range constants.SensitivePorts {
+		if from < 0 || (port >= from && port <= to) {
+			ports = append(ports, port)
+		}
+	}
+	sort.Ints(ports)
+
+	var ret []string
+	for _, port := range ports {
+		ret = append(ret, fmt.Sprintf("%d(%s)", port, constants.SensitivePorts[port]))
+	}
+
+	return strings.Join(ret, constants.DefaultDelimiter)
+}
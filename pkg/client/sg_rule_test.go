@@ -0,0 +1,83 @@
+/*
+Copyright 2020 The redhawk Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+)
+
+func TestSGRules(t *testing.T) {
+	sg := types.SecurityGroup{
+		GroupId:   aws.String("sg-1"),
+		GroupName: aws.String("web"),
+		OwnerId:   aws.String("111111111111"),
+		IpPermissions: []types.IpPermission{
+			{
+				IpProtocol: aws.String("tcp"),
+				FromPort:   aws.Int32(20),
+				ToPort:     aws.Int32(23),
+				IpRanges:   []types.IpRange{{CidrIp: aws.String("0.0.0.0/0")}, {CidrIp: aws.String("10.0.0.0/8")}},
+			},
+			{
+				IpProtocol: aws.String("-1"),
+				UserIdGroupPairs: []types.UserIdGroupPair{
+					{GroupId: aws.String("sg-2"), UserId: aws.String("111111111111")},
+					{GroupId: aws.String("sg-3"), UserId: aws.String("222222222222")},
+				},
+			},
+		},
+		IpPermissionsEgress: []types.IpPermission{
+			{
+				IpProtocol: aws.String("-1"),
+				Ipv6Ranges: []types.Ipv6Range{{CidrIpv6: aws.String("::/0")}},
+			},
+		},
+	}
+
+	tests := []struct {
+		direction      string
+		portRange      string
+		source         string
+		worldOpen      bool
+		sensitivePorts string
+	}{
+		{"ingress", "20-23", "0.0.0.0/0", true, "22(SSH)|23(Telnet)"},
+		{"ingress", "20-23", "10.0.0.0/8", false, "22(SSH)|23(Telnet)"},
+		{"ingress", "all", "sg-2", false, ""},
+		{"ingress", "all", "222222222222/sg-3", false, ""},
+		{"egress", "all", "::/0", true, ""},
+	}
+
+	rules := SGRules(sg)
+	if len(rules) != len(tests) {
+		t.Fatalf("expected %d rules, got %d", len(tests), len(rules))
+	}
+
+	for i, test := range tests {
+		r := rules[i]
+		if *r.Direction != test.direction || *r.PortRange != test.portRange || *r.Source != test.source || *r.WorldOpen != test.worldOpen {
+			t.Errorf("rule %d: unexpected %s %s %s %t", i, *r.Direction, *r.PortRange, *r.Source, *r.WorldOpen)
+		}
+
+		if test.sensitivePorts != aws.ToString(r.SensitivePorts) && len(test.sensitivePorts) > 0 {
+			t.Errorf("rule %d: expected sensitive ports %s, got %s", i, test.sensitivePorts, aws.ToString(r.SensitivePorts))
+		}
+	}
+}
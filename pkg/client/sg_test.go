@@ -0,0 +1,60 @@
+/*
+Copyright 2020 The redhawk Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+)
+
+func TestSGUsage(t *testing.T) {
+	networkInterfaces := []types.NetworkInterface{
+		{
+			Attachment: &types.NetworkInterfaceAttachment{InstanceId: aws.String("i-1")},
+			Groups:     []types.GroupIdentifier{{GroupId: aws.String("sg-web")}},
+		},
+		{
+			Description: aws.String("RDSNetworkInterface"),
+			Groups:      []types.GroupIdentifier{{GroupId: aws.String("sg-db")}, {GroupId: aws.String("sg-web")}},
+		},
+	}
+
+	attachments := SGAttachments(networkInterfaces)
+	if !reflect.DeepEqual(uniqueSorted(attachments["sg-web"]), []string{"ec2", "rds"}) {
+		t.Errorf("unexpected attachments of sg-web: %v", attachments["sg-web"])
+	}
+
+	pair := func(id string) types.IpPermission {
+		return types.IpPermission{UserIdGroupPairs: []types.UserIdGroupPair{{GroupId: aws.String(id)}}}
+	}
+	securityGroups := []types.SecurityGroup{
+		{GroupId: aws.String("sg-db"), IpPermissions: []types.IpPermission{pair("sg-app"), pair("sg-db")}},
+		{GroupId: aws.String("sg-web"), IpPermissionsEgress: []types.IpPermission{pair("sg-app")}},
+	}
+
+	references := SGReferences(securityGroups)
+	if !reflect.DeepEqual(references["sg-app"], []string{"sg-db", "sg-web"}) {
+		t.Errorf("unexpected references of sg-app: %v", references["sg-app"])
+	}
+
+	if _, ok := references["sg-db"]; ok {
+		t.Errorf("self reference is counted: %v", references["sg-db"])
+	}
+}
@@ -0,0 +1,368 @@
+/*
+Copyright 2020 The redhawk Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/identitystore"
+	"github.com/aws/aws-sdk-go-v2/service/ssoadmin"
+	"github.com/aws/aws-sdk-go-v2/service/ssoadmin/types"
+	"github.com/sirupsen/logrus"
+
+	"github.com/DevopsArtFactory/redhawk/pkg/constants"
+	"github.com/DevopsArtFactory/redhawk/pkg/resource"
+)
+
+type SSOClient struct {
+	Resource      string
+	Client        *ssoadmin.Client
+	IdentityStore *identitystore.Client
+	Region        string
+	Alias         *string
+}
+
+// GetResourceName returns resource name of client
+func (s *SSOClient) GetResourceName() string {
+	return s.Resource
+}
+
+// NewSSOClient creates a SSOClient
+func NewSSOClient(cfg aws.Config, helper Helper) (Client, error) {
+	return &SSOClient{
+		Resource:      constants.SSOResourceName,
+		Client:        GetSSOAdminClientFn(cfg),
+		IdentityStore: GetIdentityStoreClientFn(cfg),
+		Region:        helper.Region,
+	}, nil
+}
+
+// GetSSOAdminClientFn creates ssoadmin client
+func GetSSOAdminClientFn(cfg aws.Config) *ssoadmin.Client {
+	return ssoadmin.NewFromConfig(cfg)
+}
+
+// GetIdentityStoreClientFn creates identitystore client
+func GetIdentityStoreClientFn(cfg aws.Config) *identitystore.Client {
+	return identitystore.NewFromConfig(cfg)
+}
+
+// Scan scans all data
+func (s *SSOClient) Scan() ([]resource.Resource, error) {
+	var result []resource.Resource
+
+	logrus.Debug("Start scanning IAM Identity Center instances in the region")
+	instances, err := s.GetInstanceList(nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(instances) == 0 {
+		logrus.Debug("no IAM Identity Center instance found")
+		return nil, nil
+	}
+
+	for _, instance := range instances {
+		data, err := s.ScanInstance(instance)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, data...)
+	}
+
+	logrus.Debugf("total valid SSO data count: %d", len(result))
+
+	return result, nil
+}
+
+// ScanInstance scans permission sets and account assignments of the instance
+func (s *SSOClient) ScanInstance(instance types.InstanceMetadata) ([]resource.Resource, error) {
+	var result []resource.Resource
+
+	permissionSets, err := s.GetPermissionSetList(*instance.InstanceArn, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	logrus.Debugf("SSO permission sets found: %d", len(permissionSets))
+
+	// principal names are cached because same principals are assigned to many accounts
+	principalNames := map[string]string{}
+
+	for _, arn := range permissionSets {
+		ps, err := s.DescribePermissionSet(*instance.InstanceArn, arn)
+		if err != nil {
+			return nil, err
+		}
+
+		tmp := resource.SSOPermissionSetResource{
+			ResourceType: aws.String(constants.SSOPermissionSetResourceName),
+		}
+
+		tmp.Name = ps.Name
+		tmp.PermissionSetArn = ps.PermissionSetArn
+		tmp.Region = aws.String(s.Region)
+		tmp.SessionDuration = ps.SessionDuration
+		tmp.Description = ps.Description
+		tmp.Created = ps.CreatedDate
+
+		managed, err := s.GetManagedPolicies(*instance.InstanceArn, arn, nil, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var managedPolicies []string
+		for _, policy := range managed {
+			managedPolicies = append(managedPolicies, *policy.Name)
+		}
+		tmp.ManagedPolicies = aws.String(strings.Join(managedPolicies, constants.DefaultDelimiter))
+
+		customerManaged, err := s.GetCustomerManagedPolicies(*instance.InstanceArn, arn, nil, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var customerManagedPolicies []string
+		for _, policy := range customerManaged {
+			customerManagedPolicies = append(customerManagedPolicies, *policy.Name)
+		}
+		tmp.CustomerManagedPolicies = aws.String(strings.Join(customerManagedPolicies, constants.DefaultDelimiter))
+
+		inline, err := s.GetInlinePolicy(*instance.InstanceArn, arn)
+		if err != nil {
+			return nil, err
+		}
+		tmp.InlinePolicy = aws.Bool(len(inline) > 0)
+
+		accounts, err := s.GetProvisionedAccounts(*instance.InstanceArn, arn, nil, nil)
+		if err != nil {
+			return nil, err
+		}
+		tmp.AccountCount = aws.Int(len(accounts))
+		tmp.Accounts = aws.String(strings.Join(accounts, constants.DefaultDelimiter))
+
+		result = append(result, tmp)
+
+		for _, account := range accounts {
+			assignments, err := s.GetAccountAssignments(*instance.InstanceArn, arn, account, nil, nil)
+			if err != nil {
+				return nil, err
+			}
+
+			result = append(result, flattenAccountAssignments(ps.Name, s.Region, assignments, func(assignment types.AccountAssignment) string {
+				name, ok := principalNames[*assignment.PrincipalId]
+				if !ok {
+					var err error
+					name, err = s.GetPrincipalName(*instance.IdentityStoreId, assignment.PrincipalType, *assignment.PrincipalId)
+					if err != nil {
+						logrus.Debugf("cannot find principal name: %s", err.Error())
+					}
+					principalNames[*assignment.PrincipalId] = name
+				}
+				return name
+			})...)
+		}
+	}
+
+	return result, nil
+}
+
+// flattenAccountAssignments converts account assignments of a permission set into resources
+func flattenAccountAssignments(permissionSet *string, region string, assignments []types.AccountAssignment, principalName func(types.AccountAssignment) string) []resource.Resource {
+	var result []resource.Resource
+	for _, assignment := range assignments {
+		as := resource.SSOAccountAssignmentResource{
+			ResourceType: aws.String(constants.SSOAccountAssignmentResourceName),
+		}
+
+		as.PermissionSet = permissionSet
+		as.AccountID = assignment.AccountId
+		as.PrincipalType = aws.String(string(assignment.PrincipalType))
+		as.PrincipalID = assignment.PrincipalId
+		as.PrincipalName = aws.String(principalName(assignment))
+		as.Region = aws.String(region)
+
+		result = append(result, as)
+	}
+
+	return result
+}
+
+// GetInstanceList returns IAM Identity Center instances
+func (s *SSOClient) GetInstanceList(original []types.InstanceMetadata, nextToken *string) ([]types.InstanceMetadata, error) {
+	result, err := s.Client.ListInstances(context.TODO(), &ssoadmin.ListInstancesInput{
+		NextToken: nextToken,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	original = append(original, result.Instances...)
+	if result.NextToken != nil {
+		return s.GetInstanceList(original, result.NextToken)
+	}
+	return original, nil
+}
+
+// GetPermissionSetList returns ARNs of all permission sets in the instance
+func (s *SSOClient) GetPermissionSetList(instanceArn string, original []string, nextToken *string) ([]string, error) {
+	result, err := s.Client.ListPermissionSets(context.TODO(), &ssoadmin.ListPermissionSetsInput{
+		InstanceArn: aws.String(instanceArn),
+		NextToken:   nextToken,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	original = append(original, result.PermissionSets...)
+	if result.NextToken != nil {
+		return s.GetPermissionSetList(instanceArn, original, result.NextToken)
+	}
+	return original, nil
+}
+
+// DescribePermissionSet returns details of permission set
+func (s *SSOClient) DescribePermissionSet(instanceArn, permissionSetArn string) (*types.PermissionSet, error) {
+	result, err := s.Client.DescribePermissionSet(context.TODO(), &ssoadmin.DescribePermissionSetInput{
+		InstanceArn:      aws.String(instanceArn),
+		PermissionSetArn: aws.String(permissionSetArn),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result.PermissionSet, nil
+}
+
+// GetManagedPolicies returns AWS managed policies attached to permission set
+func (s *SSOClient) GetManagedPolicies(instanceArn, permissionSetArn string, original []types.AttachedManagedPolicy, nextToken *string) ([]types.AttachedManagedPolicy, error) {
+	result, err := s.Client.ListManagedPoliciesInPermissionSet(context.TODO(), &ssoadmin.ListManagedPoliciesInPermissionSetInput{
+		InstanceArn:      aws.String(instanceArn),
+		PermissionSetArn: aws.String(permissionSetArn),
+		NextToken:        nextToken,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	original = append(original, result.AttachedManagedPolicies...)
+	if result.NextToken != nil {
+		return s.GetManagedPolicies(instanceArn, permissionSetArn, original, result.NextToken)
+	}
+	return original, nil
+}
+
+// GetCustomerManagedPolicies returns customer managed policy references of permission set
+func (s *SSOClient) GetCustomerManagedPolicies(instanceArn, permissionSetArn string, original []types.CustomerManagedPolicyReference, nextToken *string) ([]types.CustomerManagedPolicyReference, error) {
+	result, err := s.Client.ListCustomerManagedPolicyReferencesInPermissionSet(context.TODO(), &ssoadmin.ListCustomerManagedPolicyReferencesInPermissionSetInput{
+		InstanceArn:      aws.String(instanceArn),
+		PermissionSetArn: aws.String(permissionSetArn),
+		NextToken:        nextToken,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	original = append(original, result.CustomerManagedPolicyReferences...)
+	if result.NextToken != nil {
+		return s.GetCustomerManagedPolicies(instanceArn, permissionSetArn, original, result.NextToken)
+	}
+	return original, nil
+}
+
+// GetInlinePolicy returns inline policy of permission set
+func (s *SSOClient) GetInlinePolicy(instanceArn, permissionSetArn string) (string, error) {
+	result, err := s.Client.GetInlinePolicyForPermissionSet(context.TODO(), &ssoadmin.GetInlinePolicyForPermissionSetInput{
+		InstanceArn:      aws.String(instanceArn),
+		PermissionSetArn: aws.String(permissionSetArn),
+	})
+	if err != nil {
+		return constants.EmptyString, err
+	}
+
+	return aws.ToString(result.InlinePolicy), nil
+}
+
+// GetProvisionedAccounts returns accounts where permission set is provisioned
+func (s *SSOClient) GetProvisionedAccounts(instanceArn, permissionSetArn string, original []string, nextToken *string) ([]string, error) {
+	result, err := s.Client.ListAccountsForProvisionedPermissionSet(context.TODO(), &ssoadmin.ListAccountsForProvisionedPermissionSetInput{
+		InstanceArn:      aws.String(instanceArn),
+		PermissionSetArn: aws.String(permissionSetArn),
+		NextToken:        nextToken,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	original = append(original, result.AccountIds...)
+	if result.NextToken != nil {
+		return s.GetProvisionedAccounts(instanceArn, permissionSetArn, original, result.NextToken)
+	}
+	return original, nil
+}
+
+// GetAccountAssignments returns principals assigned to the account with permission set
+func (s *SSOClient) GetAccountAssignments(instanceArn, permissionSetArn, account string, original []types.AccountAssignment, nextToken *string) ([]types.AccountAssignment, error) {
+	result, err := s.Client.ListAccountAssignments(context.TODO(), &ssoadmin.ListAccountAssignmentsInput{
+		InstanceArn:      aws.String(instanceArn),
+		PermissionSetArn: aws.String(permissionSetArn),
+		AccountId:        aws.String(account),
+		NextToken:        nextToken,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	original = append(original, result.AccountAssignments...)
+	if result.NextToken != nil {
+		return s.GetAccountAssignments(instanceArn, permissionSetArn, account, original, result.NextToken)
+	}
+	return original, nil
+}
+
+// GetPrincipalName returns user name or group display name of principal in identity store
+func (s *SSOClient) GetPrincipalName(identityStoreID string, principalType types.PrincipalType, principalID string) (string, error) {
+	if principalType == types.PrincipalTypeGroup {
+		result, err := s.IdentityStore.DescribeGroup(context.TODO(), &identitystore.DescribeGroupInput{
+			IdentityStoreId: aws.String(identityStoreID),
+			GroupId:         aws.String(principalID),
+		})
+		if err != nil {
+			return constants.EmptyString, err
+		}
+
+		return aws.ToString(result.DisplayName), nil
+	}
+
+	result, err := s.IdentityStore.DescribeUser(context.TODO(), &identitystore.DescribeUserInput{
+		IdentityStoreId: aws.String(identityStoreID),
+		UserId:          aws.String(principalID),
+	})
+	if err != nil {
+		return constants.EmptyString, err
+	}
+
+	return aws.ToString(result.UserName), nil
+}
+
+// SetAlias sets alias
+func (s *SSOClient) SetAlias(alias *string) {
+	s.Alias = alias
+}
@@ -0,0 +1,63 @@
+/*
+Copyright 2020 The redhawk Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ssoadmin/types"
+
+	"github.com/DevopsArtFactory/redhawk/pkg/resource"
+)
+
+func TestFlattenAccountAssignments(t *testing.T) {
+	assignments := []types.AccountAssignment{
+		{AccountId: aws.String("111111111111"), PrincipalType: types.PrincipalTypeUser, PrincipalId: aws.String("u-1")},
+		{AccountId: aws.String("111111111111"), PrincipalType: types.PrincipalTypeGroup, PrincipalId: aws.String("g-1")},
+		{AccountId: aws.String("111111111111"), PrincipalType: types.PrincipalTypeUser, PrincipalId: aws.String("u-unknown")},
+	}
+	names := map[string]string{"u-1": "alice", "g-1": "admins"}
+
+	result := flattenAccountAssignments(aws.String("AdministratorAccess"), "us-east-1", assignments, func(assignment types.AccountAssignment) string {
+		return names[*assignment.PrincipalId]
+	})
+	if len(result) != len(assignments) {
+		t.Fatalf("expected %d assignments, got %d", len(assignments), len(result))
+	}
+
+	expected := []struct {
+		principalType string
+		principalName string
+	}{
+		{"USER", "alice"},
+		{"GROUP", "admins"},
+		{"USER", ""},
+	}
+	for i, r := range result {
+		as, ok := r.(resource.SSOAccountAssignmentResource)
+		if !ok {
+			t.Fatalf("unexpected resource type %T", r)
+		}
+		if *as.PermissionSet != "AdministratorAccess" || *as.AccountID != "111111111111" || *as.Region != "us-east-1" {
+			t.Errorf("unexpected assignment fields: %+v", as)
+		}
+		if *as.PrincipalType != expected[i].principalType || *as.PrincipalName != expected[i].principalName {
+			t.Errorf("expected %s %q, got %s %q", expected[i].principalType, expected[i].principalName, *as.PrincipalType, *as.PrincipalName)
+		}
+	}
+}
@@ -48,3 +48,13 @@ func (s STSClient) CheckWhoIam() (*string, error) {
 
 	return result.Arn, nil
 }
+
+// GetAccountID returns account ID of current credentials
+func (s STSClient) GetAccountID() (*string, error) {
+	result, err := s.Client.GetCallerIdentity(context.TODO(), &sts.GetCallerIdentityInput{})
+	if err != nil {
+		return nil, err
+	}
+
+	return result.Account, nil
+}
@@ -0,0 +1,144 @@
+/*
+Copyright 2020 The redhawk Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/sirupsen/logrus"
+
+	"github.com/DevopsArtFactory/redhawk/pkg/constants"
+	"github.com/DevopsArtFactory/redhawk/pkg/resource"
+)
+
+// activeFlowLogStatus is the status of flow logs which are capturing traffic
+const activeFlowLogStatus = "ACTIVE"
+
+type VPCClient struct {
+	Resource string
+	Client   *ec2.Client
+	Region   string
+	Alias    *string
+}
+
+// GetResourceName returns resource name of client
+func (v *VPCClient) GetResourceName() string {
+	return v.Resource
+}
+
+// NewVPCClient creates a VPCClient
+func NewVPCClient(cfg aws.Config, helper Helper) (Client, error) {
+	return &VPCClient{
+		Resource: constants.VPCResourceName,
+		Client:   GetEC2ClientFn(cfg),
+		Region:   helper.Region,
+	}, nil
+}
+
+// Scan scans all VPCs with their active flow logs
+func (v *VPCClient) Scan() ([]resource.Resource, error) {
+	var result []resource.Resource
+
+	logrus.Debug("Start scanning all VPCs in the region")
+	vpcs, err := v.GetVPCs(nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(vpcs) == 0 {
+		logrus.Debug("no vpc found")
+		return nil, nil
+	}
+
+	flowLogs, err := v.GetFlowLogs(nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	active := map[string][]string{}
+	for _, flowLog := range flowLogs {
+		if aws.ToString(flowLog.FlowLogStatus) == activeFlowLogStatus {
+			id := aws.ToString(flowLog.ResourceId)
+			active[id] = append(active[id], aws.ToString(flowLog.FlowLogId))
+		}
+	}
+
+	for _, vpc := range vpcs {
+		tmp := resource.VPCResource{
+			ResourceType:    aws.String(constants.VPCResourceName),
+			VpcID:           vpc.VpcId,
+			CidrBlock:       vpc.CidrBlock,
+			Default:         aws.Bool(aws.ToBool(vpc.IsDefault)),
+			Region:          aws.String(v.Region),
+			FlowLogsEnabled: aws.Bool(len(active[aws.ToString(vpc.VpcId)]) > 0),
+		}
+
+		if name, ok := ec2Tags(vpc.Tags)["Name"]; ok {
+			tmp.Name = aws.String(name)
+		}
+
+		if ids := active[aws.ToString(vpc.VpcId)]; len(ids) > 0 {
+			tmp.FlowLogs = aws.String(strings.Join(ids, constants.DefaultDelimiter))
+		}
+
+		result = append(result, tmp)
+	}
+	logrus.Debugf("total vpc count: %d", len(result))
+
+	return result, nil
+}
+
+// GetVPCs returns all VPCs in the region
+func (v *VPCClient) GetVPCs(original []types.Vpc, nextToken *string) ([]types.Vpc, error) {
+	result, err := v.Client.DescribeVpcs(context.TODO(), &ec2.DescribeVpcsInput{
+		NextToken: nextToken,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	original = append(original, result.Vpcs...)
+	if result.NextToken != nil {
+		return v.GetVPCs(original, result.NextToken)
+	}
+	return original, nil
+}
+
+// GetFlowLogs returns all flow logs in the region
+func (v *VPCClient) GetFlowLogs(original []types.FlowLog, nextToken *string) ([]types.FlowLog, error) {
+	result, err := v.Client.DescribeFlowLogs(context.TODO(), &ec2.DescribeFlowLogsInput{
+		NextToken: nextToken,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	original = append(original, result.FlowLogs...)
+	if result.NextToken != nil {
+		return v.GetFlowLogs(original, result.NextToken)
+	}
+	return original, nil
+}
+
+// SetAlias sets alias
+func (v *VPCClient) SetAlias(alias *string) {
+	v.Alias = alias
+}
@@ -18,6 +18,7 @@ package constants
 
 import (
 	"os"
+	"time"
 
 	"github.com/sirupsen/logrus"
 )
@@ -41,6 +42,9 @@ const (
 	// DefaultDelimiter is a default delimiter for csv output
 	DefaultDelimiter = "|"
 
+	// DefaultIgnoreFile is a default suppression file for audit
+	DefaultIgnoreFile = ".redhawk-ignore.yaml"
+
 	// DefaultRegionVariable is the default region id
 	DefaultRegionVariable = "AWS_DEFAULT_REGION"
 
@@ -49,15 +53,132 @@ const (
 
 	// Resource Name Constants
 	// After add resource here, you have to setup `ResourceConfig` in the var section
-	EC2ResourceName      = "ec2"
-	SGResourceName       = "security_group"
-	Route53ResourceName  = "route53"
-	S3ResourceName       = "s3"
-	RDSResourceName      = "rds"
-	IAMResourceName      = "iam"
-	IAMUserResourceName  = "iam_user"
-	IAMGroupResourceName = "iam_group"
-	IAMRoleResourceName  = "iam_role"
+	EC2ResourceName           = "ec2"
+	SGResourceName            = "security_group"
+	SGRuleResourceName        = "security_group_rule"
+	ExposureResourceName      = "exposure"
+	Route53ResourceName       = "route53"
+	DanglingDNSResourceName   = "dangling_dns"
+	S3ResourceName            = "s3"
+	RDSResourceName           = "rds"
+	IAMResourceName           = "iam"
+	IAMUserResourceName       = "iam_user"
+	IAMGroupResourceName      = "iam_group"
+	IAMRoleResourceName       = "iam_role"
+	IAMPolicyResourceName     = "iam_policy"
+	IAMAccessKeyResourceName  = "iam_access_key"
+	IAMEscalationResourceName = "iam_escalation"
+	EFSResourceName           = "efs"
+	FSxResourceName           = "fsx"
+	KinesisResourceName       = "kinesis"
+	MSKResourceName           = "msk"
+	AccountResourceName       = "account"
+	EIPResourceName           = "eip"
+	KeyPairResourceName       = "key_pair"
+	SecretResourceName        = "secret"
+	LoadBalancerResourceName  = "load_balancer"
+	CloudTrailResourceName    = "cloudtrail"
+	VPCResourceName           = "vpc"
+	CloudFrontResourceName    = "cloudfront"
+	BeanstalkResourceName     = "elastic_beanstalk"
+
+	OrganizationResourceName        = "organization"
+	OrganizationAccountResourceName = "organization_account"
+	OrganizationUnitResourceName    = "organization_unit"
+	OrganizationPolicyResourceName  = "organization_policy"
+
+	SSOResourceName                  = "sso"
+	SSOPermissionSetResourceName     = "sso_permission_set"
+	SSOAccountAssignmentResourceName = "sso_account_assignment"
+
+	// Types of IAM policy
+	IAMPolicyTypeManaged = "MANAGED"
+	IAMPolicyTypeInline  = "INLINE"
+
+	// Classifications of principals trusted by IAM role
+	TrustSameAccount            = "same-account"
+	TrustKnownOrgAccount        = "known-org-account"
+	TrustUnknownExternalAccount = "unknown-external-account"
+	TrustPublic                 = "public"
+
+	// MaxEscalationDepth is the maximum number of roles assumed in a privilege escalation path
+	MaxEscalationDepth = 5
+
+	// RootAccountUser is the user name of root account in IAM credential report
+	RootAccountUser = "<root_account>"
+
+	// CredentialReportRetryCount is the number of checks until IAM credential report is generated
+	CredentialReportRetryCount = 10
+
+	// CredentialReportRetryInterval is the interval between checks of IAM credential report generation
+	CredentialReportRetryInterval = 2 * time.Second
+
+	// AccountIDPlaceholder is replaced with account ID in role template of organization
+	AccountIDPlaceholder = "{{id}}"
+
+	// StateTransitionTimeFormat is the time format in state transition reason of EC2 instance
+	StateTransitionTimeFormat = "2006-01-02 15:04:05"
+
+	// DefaultSGName is the name of default security group of VPC
+	DefaultSGName = "default"
+
+	// Usages of security groups
+	SGUsageInUse          = "in-use"
+	SGUsageReferencedOnly = "referenced-only"
+	SGUsageUnused         = "unused"
+
+	// Directions of security group rules
+	SGRuleIngress = "ingress"
+	SGRuleEgress  = "egress"
+
+	// Types of sources or destinations of security group rules
+	SGRuleSourceCIDR          = "cidr"
+	SGRuleSourceIPv6          = "ipv6"
+	SGRuleSourcePrefixList    = "prefix_list"
+	SGRuleSourceSecurityGroup = "security_group"
+
+	// SGRuleAllProtocols is the protocol of rules which allow all traffic
+	SGRuleAllProtocols = "all"
+
+	// LoadBalancerTargetType is the type of load balancers in exposure analysis
+	LoadBalancerTargetType = "load_balancer"
+
+	// Access classifications of S3 buckets
+	S3AccessPublic       = "public"
+	S3AccessCrossAccount = "cross-account"
+	S3AccessPrivate      = "private"
+
+	// Statuses of DNS records checked for dangling targets
+	DNSStatusOK         = "ok"
+	DNSStatusDangling   = "dangling"
+	DNSStatusUnownedIP  = "unowned-ip"
+	DNSStatusUnverified = "unverified"
+
+	// Sources of secrets
+	SecretSourceUserData               = "ec2_user_data"
+	SecretSourceLaunchTemplateUserData = "launch_template_user_data"
+	SecretSourceLambdaEnvironment      = "lambda_environment"
+
+	// Default encryptions of S3 buckets
+	S3EncryptionSSES3  = "SSE-S3"
+	S3EncryptionSSEKMS = "SSE-KMS"
+	S3EncryptionNone   = "NONE"
+
+	// Severities of audit findings
+	SeverityInfo     = "info"
+	SeverityLow      = "low"
+	SeverityMedium   = "medium"
+	SeverityHigh     = "high"
+	SeverityCritical = "critical"
+
+	FrameworkCIS      = "cis"
+	FrameworkSOC2     = "soc2"
+	FrameworkISO27001 = "iso27001"
+	FrameworkPCI      = "pci"
+
+	// DefaultMSKRetentionHours is the broker default of log.retention.hours
+	// applied when a MSK cluster has no custom configuration
+	DefaultMSKRetentionHours = 168
 )
 
 var (
@@ -81,6 +202,124 @@ var (
 		"us-west-2",
 	}
 
+	// WorldOpenCIDRs are CIDR blocks which mean the whole internet
+	WorldOpenCIDRs = []string{
+		"0.0.0.0/0",
+		"::/0",
+	}
+
+	// PublicACLGroups are grantees of S3 ACL which mean everyone
+	PublicACLGroups = map[string]string{
+		"http://acs.amazonaws.com/groups/global/AllUsers":           "AllUsers",
+		"http://acs.amazonaws.com/groups/global/AuthenticatedUsers": "AuthenticatedUsers",
+	}
+
+	// RestrictingConditionKeys are condition keys which limit wildcard principal to specific networks or accounts
+	RestrictingConditionKeys = []string{
+		"aws:sourceip",
+		"aws:sourcevpc",
+		"aws:sourcevpce",
+		"aws:principalorgid",
+		"aws:principalorgpaths",
+		"aws:principalaccount",
+		"aws:principalarn",
+		"aws:sourceaccount",
+		"aws:sourcearn",
+		"aws:sourceowner",
+	}
+
+	// DNSTargetResources are resources which are looked up as targets of Route53 records
+	DNSTargetResources = []string{
+		S3ResourceName,
+		EIPResourceName,
+		LoadBalancerResourceName,
+		CloudFrontResourceName,
+		BeanstalkResourceName,
+	}
+
+	// ScannedResources are resources which are scanned to find resources of the type
+	ScannedResources = map[string]string{
+		IAMUserResourceName:      IAMResourceName,
+		IAMGroupResourceName:     IAMResourceName,
+		IAMRoleResourceName:      IAMResourceName,
+		IAMPolicyResourceName:    IAMResourceName,
+		IAMAccessKeyResourceName: IAMResourceName,
+		DanglingDNSResourceName:  Route53ResourceName,
+	}
+
+	// TaggableResources are resources which have tags
+	TaggableResources = []string{
+		EC2ResourceName,
+		SGResourceName,
+		EIPResourceName,
+		KeyPairResourceName,
+		S3ResourceName,
+		RDSResourceName,
+		EFSResourceName,
+		FSxResourceName,
+		MSKResourceName,
+		IAMUserResourceName,
+		IAMRoleResourceName,
+	}
+
+	// WebPorts are ports of web services
+	WebPorts = []int{
+		80,
+		443,
+	}
+
+	// SensitivePorts are ports of administration and database services which should not be open to the internet
+	SensitivePorts = map[int]string{
+		22:    "SSH",
+		23:    "Telnet",
+		135:   "RPC",
+		445:   "SMB",
+		1433:  "MSSQL",
+		1521:  "Oracle",
+		2375:  "Docker",
+		2379:  "etcd",
+		3306:  "MySQL",
+		3389:  "RDP",
+		5432:  "PostgreSQL",
+		5601:  "Kibana",
+		5900:  "VNC",
+		6379:  "Redis",
+		9092:  "Kafka",
+		9200:  "Elasticsearch",
+		11211: "Memcached",
+		27017: "MongoDB",
+	}
+
+	// NetworkInterfaceOwners are prefixes of descriptions of network interfaces created by AWS services
+	NetworkInterfaceOwners = map[string]string{
+		"RDSNetworkInterface":       "rds",
+		"ELB ":                      "elb",
+		"AWS Lambda VPC ENI":        "lambda",
+		"arn:aws:ecs:":              "ecs",
+		"EFS mount target":          "efs",
+		"ElastiCache":               "elasticache",
+		"Interface for NAT Gateway": "nat_gateway",
+		"VPC Endpoint Interface":    "vpc_endpoint",
+		"AWS created network interface for directory": "directory_service",
+	}
+
+	// Severities is a list of severities of audit findings in ascending order
+	Severities = []string{
+		SeverityInfo,
+		SeverityLow,
+		SeverityMedium,
+		SeverityHigh,
+		SeverityCritical,
+	}
+
+	// Frameworks are compliance frameworks which rules are mapped to
+	Frameworks = []string{
+		FrameworkCIS,
+		FrameworkSOC2,
+		FrameworkISO27001,
+		FrameworkPCI,
+	}
+
 	// ValidFormats is a list of valid output format for scan data
 	ValidFormats = []string{
 		"stdout",
@@ -88,12 +327,33 @@ var (
 	}
 
 	ResourceGlobal = map[string]bool{
-		EC2ResourceName:     false,
-		SGResourceName:      false,
-		Route53ResourceName: false,
-		S3ResourceName:      false,
-		RDSResourceName:     false,
-		IAMResourceName:     true,
+		EC2ResourceName:      false,
+		SGResourceName:       false,
+		SGRuleResourceName:   false,
+		ExposureResourceName: false,
+		Route53ResourceName:  true,
+		S3ResourceName:       false,
+		RDSResourceName:      false,
+		IAMResourceName:      true,
+		EFSResourceName:      false,
+		FSxResourceName:      false,
+		KinesisResourceName:  false,
+		MSKResourceName:      false,
+		AccountResourceName:  true,
+
+		EIPResourceName:          false,
+		KeyPairResourceName:      false,
+		SecretResourceName:       false,
+		LoadBalancerResourceName: false,
+		CloudTrailResourceName:   false,
+		VPCResourceName:          false,
+		CloudFrontResourceName:   true,
+		BeanstalkResourceName:    false,
+
+		OrganizationResourceName: true,
+		SSOResourceName:          false,
+
+		IAMEscalationResourceName: true,
 	}
 
 	// AWSCredentialsPath is the file path of aws credentials
@@ -107,10 +367,18 @@ var (
 			Name:    EC2ResourceName,
 			Default: true,
 		},
+		{
+			Name:    KeyPairResourceName,
+			Default: true,
+		},
 		{
 			Name:    SGResourceName,
 			Default: true,
 		},
+		{
+			Name:    SGRuleResourceName,
+			Default: true,
+		},
 		{
 			Name:    Route53ResourceName,
 			Default: true,
@@ -127,6 +395,70 @@ var (
 			Name:    IAMResourceName,
 			Default: true,
 		},
+		{
+			Name:    EFSResourceName,
+			Default: true,
+		},
+		{
+			Name:    FSxResourceName,
+			Default: true,
+		},
+		{
+			Name:    KinesisResourceName,
+			Default: true,
+		},
+		{
+			Name:    MSKResourceName,
+			Default: true,
+		},
+		{
+			Name:    AccountResourceName,
+			Default: true,
+		},
+		{
+			Name:    EIPResourceName,
+			Default: false,
+		},
+		{
+			Name:    LoadBalancerResourceName,
+			Default: true,
+		},
+		{
+			Name:    CloudTrailResourceName,
+			Default: true,
+		},
+		{
+			Name:    VPCResourceName,
+			Default: true,
+		},
+		{
+			Name:    CloudFrontResourceName,
+			Default: false,
+		},
+		{
+			Name:    BeanstalkResourceName,
+			Default: false,
+		},
+		{
+			Name:    OrganizationResourceName,
+			Default: false,
+		},
+		{
+			Name:    SSOResourceName,
+			Default: false,
+		},
+		{
+			Name:    IAMEscalationResourceName,
+			Default: false,
+		},
+		{
+			Name:    ExposureResourceName,
+			Default: false,
+		},
+		{
+			Name:    SecretResourceName,
+			Default: false,
+		},
 	}
 )
 
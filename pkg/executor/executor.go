@@ -32,12 +32,22 @@ var NewExecutor = createNewExecutor
 
 // RunExecutor for command line
 func RunExecutor(ctx context.Context, action func(Executor) error) error {
+	return runExecutor(ctx, builder.ValidateFlags, action)
+}
+
+// RunAuditExecutor for audit command which does not require resources
+func RunAuditExecutor(ctx context.Context, action func(Executor) error) error {
+	return runExecutor(ctx, builder.ValidateAuditFlags, action)
+}
+
+// runExecutor validates flags and runs function with executor
+func runExecutor(ctx context.Context, validate func(builder.Flags) error, action func(Executor) error) error {
 	flags, err := builder.GetFlags()
 	if err != nil {
 		return err
 	}
 
-	if err := builder.ValidateFlags(flags); err != nil {
+	if err := validate(flags); err != nil {
 		return err
 	}
 
@@ -21,8 +21,10 @@ import (
 	"encoding/csv"
 	"fmt"
 	"os"
+	"strconv"
 	"time"
 
+	"github.com/DevopsArtFactory/redhawk/pkg/audit"
 	"github.com/DevopsArtFactory/redhawk/pkg/constants"
 	"github.com/DevopsArtFactory/redhawk/pkg/resource"
 	"github.com/DevopsArtFactory/redhawk/pkg/tools"
@@ -31,6 +33,7 @@ import (
 type CSVPrinter struct {
 	Out      *os.File
 	Provider string
+	Account  string
 	Data     map[string][][]string
 }
 
@@ -39,7 +42,7 @@ func NewCSVPrinter() Printer {
 }
 
 // SetData sets data
-func (c CSVPrinter) SetData(provider string, d []resource.Resource) (Printer, error) {
+func (c CSVPrinter) SetData(provider, account string, d []resource.Resource) (Printer, error) {
 	ret := map[string][][]string{}
 	for _, resource := range d {
 		rt := resource.GetResource()
@@ -60,14 +63,14 @@ func (c CSVPrinter) SetData(provider string, d []resource.Resource) (Printer, er
 		}
 
 		tmp := b
-		policyIndex := 6
-		if resource.GetResource() == constants.S3ResourceName && len(b[policyIndex]) > 0 {
+		policyIndex := indexOf(ret[rt][0], "Policy")
+		if resource.GetResource() == constants.S3ResourceName && policyIndex >= 0 && len(b[policyIndex]) > 0 {
 			decodedPolicy, err := base64.StdEncoding.DecodeString(b[policyIndex])
 			if err != nil {
 				return nil, err
 			}
-			tmp = b[:policyIndex]
-			tmp = append(tmp, string(decodedPolicy))
+			b[policyIndex] = string(decodedPolicy)
+			tmp = b
 		}
 
 		routeToIndex := 4
@@ -85,6 +88,7 @@ func (c CSVPrinter) SetData(provider string, d []resource.Resource) (Printer, er
 
 	c.Data = ret
 	c.Provider = provider
+	c.Account = account
 
 	return c, nil
 }
@@ -93,7 +97,7 @@ func (c CSVPrinter) SetData(provider string, d []resource.Resource) (Printer, er
 func (c CSVPrinter) Print() error {
 	now := time.Now().Unix()
 	for key, dataList := range c.Data {
-		filePath := getRandomFilePath(c.Provider, key, now)
+		filePath := getRandomFilePath(c.Provider, c.Account, key, now)
 		if !tools.FileExists(filePath) {
 			f, err := os.Create(filePath)
 			if err != nil {
@@ -120,6 +124,109 @@ func (c CSVPrinter) Print() error {
 }
 
 // getRandomFilePath creates filename for csv
-func getRandomFilePath(provider, key string, now int64) string {
+func getRandomFilePath(provider, account, key string, now int64) string {
+	if len(account) > 0 {
+		return fmt.Sprintf("%s-%d-%s-%s.csv", provider, now, tools.SanitizeFileName(account), key)
+	}
 	return fmt.Sprintf("%s-%d-%s.csv", provider, now, key)
 }
+
+type CSVFindingPrinter struct {
+	Provider   string
+	Data       [][]string
+	Controls   [][]string
+	Summary    []audit.SeverityCount
+	Suppressed int
+}
+
+func NewCSVFindingPrinter() FindingPrinter {
+	return CSVFindingPrinter{}
+}
+
+// SetFindings sets findings
+func (c CSVFindingPrinter) SetFindings(provider string, report audit.Report) (FindingPrinter, error) {
+	ret := [][]string{
+		{"severity", "rule_id", "title", "account", "region", "resource_type", "resource_id", "message"},
+	}
+
+	if len(report.Framework) > 0 {
+		ret[0] = append([]string{report.Framework}, ret[0]...)
+		for _, f := range report.ByControl {
+			ret = append(ret, []string{f.Control, f.Severity, f.RuleID, f.Title, f.Account, f.Region, f.ResourceType, f.ResourceID, f.Message})
+		}
+	} else {
+		for _, f := range report.Findings {
+			ret = append(ret, []string{f.Severity, f.RuleID, f.Title, f.Account, f.Region, f.ResourceType, f.ResourceID, f.Message})
+		}
+	}
+
+	c.Provider = provider
+	c.Data = ret
+	c.Summary = report.Summary()
+	c.Suppressed = len(report.Suppressed)
+
+	if len(report.Controls) > 0 {
+		c.Controls = [][]string{
+			{"control", "title", "status", "findings", "note"},
+		}
+		for _, r := range report.Controls {
+			c.Controls = append(c.Controls, []string{r.ID, r.Title, r.Status, strconv.Itoa(r.Findings), r.Note})
+		}
+	}
+
+	return c, nil
+}
+
+// Print writes findings and controls to csv files and shows the summary to Standard Out
+func (c CSVFindingPrinter) Print() error {
+	now := time.Now().Unix()
+	filePath := fmt.Sprintf("%s-%d-findings.csv", c.Provider, now)
+	if err := writeCSV(filePath, c.Data); err != nil {
+		return err
+	}
+	fmt.Printf("findings are written to %s\n", filePath)
+
+	if len(c.Controls) > 0 {
+		filePath = fmt.Sprintf("%s-%d-controls.csv", c.Provider, now)
+		if err := writeCSV(filePath, c.Controls); err != nil {
+			return err
+		}
+		fmt.Printf("controls are written to %s\n", filePath)
+	}
+
+	for _, s := range c.Summary {
+		fmt.Printf("%s: %d\n", s.Severity, s.Count)
+	}
+
+	if c.Suppressed > 0 {
+		fmt.Printf("suppressed: %d\n", c.Suppressed)
+	}
+
+	return nil
+}
+
+// writeCSV writes rows to a csv file
+func writeCSV(filePath string, rows [][]string) error {
+	f, err := os.Create(filePath)
+	if err != nil {
+		return fmt.Errorf("cannot create file: %s", filePath)
+	}
+	defer f.Close()
+
+	w := csv.NewWriter(f)
+	if err := w.WriteAll(rows); err != nil {
+		return err
+	}
+
+	return w.Error()
+}
+
+// indexOf returns index of the header, or -1 if header does not exist
+func indexOf(headers []string, header string) int {
+	for i, h := range headers {
+		if h == header {
+			return i
+		}
+	}
+	return -1
+}
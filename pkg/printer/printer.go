@@ -19,12 +19,18 @@ package printer
 import (
 	"fmt"
 
+	"github.com/DevopsArtFactory/redhawk/pkg/audit"
 	"github.com/DevopsArtFactory/redhawk/pkg/resource"
 )
 
 type Printer interface {
 	Print() error
-	SetData(string, []resource.Resource) (Printer, error)
+	SetData(string, string, []resource.Resource) (Printer, error)
+}
+
+type FindingPrinter interface {
+	Print() error
+	SetFindings(string, audit.Report) (FindingPrinter, error)
 }
 
 // SelectPrinter creates new printers
@@ -35,3 +41,12 @@ func SelectPrinter(outputType string) (Printer, error) {
 	}
 	return f(), nil
 }
+
+// SelectFindingPrinter creates new printers for audit findings
+func SelectFindingPrinter(outputType string) (FindingPrinter, error) {
+	f, ok := findingPrinters[outputType]
+	if !ok {
+		return nil, fmt.Errorf("output type %s is not available for printer", outputType)
+	}
+	return f(), nil
+}
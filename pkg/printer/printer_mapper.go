@@ -21,4 +21,9 @@ var (
 		"stdout": NewStdOutPrinter,
 		"csv":    NewCSVPrinter,
 	}
+
+	findingPrinters = map[string]func() FindingPrinter{
+		"stdout": NewStdOutFindingPrinter,
+		"csv":    NewCSVFindingPrinter,
+	}
 )
@@ -21,10 +21,12 @@ import (
 	"io"
 	"os"
 	"text/tabwriter"
+	textTemplate "text/template"
 
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/viper"
 
+	"github.com/DevopsArtFactory/redhawk/pkg/audit"
 	"github.com/DevopsArtFactory/redhawk/pkg/color"
 	"github.com/DevopsArtFactory/redhawk/pkg/resource"
 	"github.com/DevopsArtFactory/redhawk/pkg/templates"
@@ -34,6 +36,7 @@ import (
 type StdOutPrinter struct {
 	Out      io.Writer
 	Provider string
+	Account  string
 	Data     map[string][]resource.Resource
 }
 
@@ -43,7 +46,7 @@ func NewStdOutPrinter() Printer {
 }
 
 // SetData sets data
-func (s StdOutPrinter) SetData(provider string, d []resource.Resource) (Printer, error) {
+func (s StdOutPrinter) SetData(provider, account string, d []resource.Resource) (Printer, error) {
 	ret := map[string][]resource.Resource{}
 	for _, r := range d {
 		rt := r.GetResource()
@@ -56,6 +59,7 @@ func (s StdOutPrinter) SetData(provider string, d []resource.Resource) (Printer,
 	s.Data = ret
 	s.Out = os.Stdout
 	s.Provider = provider
+	s.Account = account
 	return s, nil
 }
 
@@ -66,10 +70,12 @@ func (s StdOutPrinter) Print() error {
 	var scanData = struct {
 		Summary  map[string][]resource.Resource
 		Provider string
+		Account  string
 		Detail   bool
 	}{
 		Summary:  s.Data,
 		Provider: s.Provider,
+		Account:  s.Account,
 		Detail:   detail,
 	}
 
@@ -88,3 +94,60 @@ func (s StdOutPrinter) Print() error {
 	}
 	return w.Flush()
 }
+
+type StdOutFindingPrinter struct {
+	Out      io.Writer
+	Provider string
+	Report   audit.Report
+}
+
+// NewStdOutFindingPrinter creates a new stdout printer for findings
+func NewStdOutFindingPrinter() FindingPrinter {
+	return StdOutFindingPrinter{}
+}
+
+// SetFindings sets findings
+func (s StdOutFindingPrinter) SetFindings(provider string, report audit.Report) (FindingPrinter, error) {
+	s.Out = os.Stdout
+	s.Provider = provider
+	s.Report = report
+	return s, nil
+}
+
+// Print shows findings to Standard Out
+func (s StdOutFindingPrinter) Print() error {
+	var data = struct {
+		Provider   string
+		Findings   []audit.Finding
+		Summary    []audit.SeverityCount
+		Suppressed int
+
+		Benchmark      string
+		Controls       []audit.ControlResult
+		ControlSummary map[string]int
+
+		Framework string
+		ByControl []audit.ControlFinding
+	}{
+		Provider:   s.Provider,
+		Findings:   s.Report.Findings,
+		Summary:    s.Report.Summary(),
+		Suppressed: len(s.Report.Suppressed),
+
+		Benchmark:      s.Report.Benchmark,
+		Controls:       s.Report.Controls,
+		ControlSummary: s.Report.ControlSummary(),
+
+		Framework: s.Report.Framework,
+		ByControl: s.Report.ByControl,
+	}
+
+	// Messages of findings are not HTML, so text/template is used
+	w := tabwriter.NewWriter(s.Out, 0, 5, 3, ' ', tabwriter.TabIndent)
+	t := textTemplate.Must(textTemplate.New("Findings").Parse(templates.FindingTemplate))
+
+	if err := t.Execute(w, data); err != nil {
+		return err
+	}
+	return w.Flush()
+}
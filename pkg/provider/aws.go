@@ -32,9 +32,10 @@ func NewAWSProvider() Provider {
 	}
 }
 
-// CreateClient creates a new resource-specific client
-func (a AWSProvider) CreateClient(region string, resource string) (client.Client, error) {
-	return client.CreateResourceClient(a.Provider, resource, region)
+// CreateClient creates a new resource-specific client.
+// Client uses credentials of roleArn if it is specified
+func (a AWSProvider) CreateClient(roleArn, region, resource string) (client.Client, error) {
+	return client.CreateResourceClient(a.Provider, resource, region, roleArn)
 }
 
 // GetProvider returns provider
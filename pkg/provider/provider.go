@@ -23,7 +23,7 @@ import (
 )
 
 type Provider interface {
-	CreateClient(string, string) (client.Client, error)
+	CreateClient(string, string, string) (client.Client, error)
 	GetProvider() string
 }
 
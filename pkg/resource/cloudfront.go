@@ -0,0 +1,63 @@
+/*
+Copyright 2020 The redhawk Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resource
+
+import (
+	"strings"
+
+	"github.com/jszwec/csvutil"
+)
+
+// GetResource returns resource type
+func (c CloudFrontResource) GetResource() string {
+	return *c.ResourceType
+}
+
+// GetHeaders returns headers
+func (c CloudFrontResource) GetHeaders() ([]string, error) {
+	strSlice, err := c.StructToSliceLine()
+	if err != nil {
+		return nil, err
+	}
+
+	return strings.Split(strSlice[0], ","), nil
+}
+
+// TransferToCSV change struct to CSV
+func (c CloudFrontResource) TransferToCSV() ([]string, error) {
+	strSlice, err := c.StructToSliceLine()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(strSlice) <= 1 {
+		return nil, nil
+	}
+
+	return strings.Split(strSlice[1], ","), nil
+}
+
+// StructToSliceLine returns header and rows
+func (c CloudFrontResource) StructToSliceLine() ([]string, error) {
+	b, err := csvutil.Marshal([]CloudFrontResource{c})
+	if err != nil {
+		return nil, err
+	}
+	split := strings.Split(string(b), "\n")
+
+	return split, nil
+}
@@ -150,3 +150,132 @@ func (i IAMRoleResource) StructToSliceLine() ([]string, error) {
 
 	return split, nil
 }
+
+/*
+	IAM POLICY
+*/
+// GetResource returns resource type
+func (i IAMPolicyResource) GetResource() string {
+	return *i.ResourceType
+}
+
+// GetHeaders returns headers
+func (i IAMPolicyResource) GetHeaders() ([]string, error) {
+	strSlice, err := i.StructToSliceLine()
+	if err != nil {
+		return nil, err
+	}
+
+	return strings.Split(strSlice[0], ","), nil
+}
+
+// TransferToCSV change struct to CSV
+func (i IAMPolicyResource) TransferToCSV() ([]string, error) {
+	strSlice, err := i.StructToSliceLine()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(strSlice) <= 1 {
+		return nil, nil
+	}
+
+	return strings.Split(strSlice[1], ","), nil
+}
+
+// StructToSliceLine returns header and rows
+func (i IAMPolicyResource) StructToSliceLine() ([]string, error) {
+	b, err := csvutil.Marshal([]IAMPolicyResource{i})
+	if err != nil {
+		return nil, err
+	}
+	split := strings.Split(string(b), "\n")
+
+	return split, nil
+}
+
+/*
+	IAM ACCESS KEY
+*/
+// GetResource returns resource type
+func (i IAMAccessKeyResource) GetResource() string {
+	return *i.ResourceType
+}
+
+// GetHeaders returns headers
+func (i IAMAccessKeyResource) GetHeaders() ([]string, error) {
+	strSlice, err := i.StructToSliceLine()
+	if err != nil {
+		return nil, err
+	}
+
+	return strings.Split(strSlice[0], ","), nil
+}
+
+// TransferToCSV change struct to CSV
+func (i IAMAccessKeyResource) TransferToCSV() ([]string, error) {
+	strSlice, err := i.StructToSliceLine()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(strSlice) <= 1 {
+		return nil, nil
+	}
+
+	return strings.Split(strSlice[1], ","), nil
+}
+
+// StructToSliceLine returns header and rows
+func (i IAMAccessKeyResource) StructToSliceLine() ([]string, error) {
+	b, err := csvutil.Marshal([]IAMAccessKeyResource{i})
+	if err != nil {
+		return nil, err
+	}
+	split := strings.Split(string(b), "\n")
+
+	return split, nil
+}
+
+/*
+	IAM ESCALATION
+*/
+// GetResource returns resource type
+func (i IAMEscalationResource) GetResource() string {
+	return *i.ResourceType
+}
+
+// GetHeaders returns headers
+func (i IAMEscalationResource) GetHeaders() ([]string, error) {
+	strSlice, err := i.StructToSliceLine()
+	if err != nil {
+		return nil, err
+	}
+
+	return strings.Split(strSlice[0], ","), nil
+}
+
+// TransferToCSV change struct to CSV
+func (i IAMEscalationResource) TransferToCSV() ([]string, error) {
+	strSlice, err := i.StructToSliceLine()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(strSlice) <= 1 {
+		return nil, nil
+	}
+
+	return strings.Split(strSlice[1], ","), nil
+}
+
+// StructToSliceLine returns header and rows
+func (i IAMEscalationResource) StructToSliceLine() ([]string, error) {
+	b, err := csvutil.Marshal([]IAMEscalationResource{i})
+	if err != nil {
+		return nil, err
+	}
+	split := strings.Split(string(b), "\n")
+
+	return split, nil
+}
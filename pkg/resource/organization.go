@@ -0,0 +1,152 @@
+/*
+Copyright 2020 The redhawk Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resource
+
+import (
+	"strings"
+
+	"github.com/jszwec/csvutil"
+)
+
+/*
+	ORGANIZATION ACCOUNT
+*/
+// GetResource returns resource type
+func (o OrganizationAccountResource) GetResource() string {
+	return *o.ResourceType
+}
+
+// GetHeaders returns headers
+func (o OrganizationAccountResource) GetHeaders() ([]string, error) {
+	strSlice, err := o.StructToSliceLine()
+	if err != nil {
+		return nil, err
+	}
+
+	return strings.Split(strSlice[0], ","), nil
+}
+
+// TransferToCSV change struct to CSV
+func (o OrganizationAccountResource) TransferToCSV() ([]string, error) {
+	strSlice, err := o.StructToSliceLine()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(strSlice) <= 1 {
+		return nil, nil
+	}
+
+	return strings.Split(strSlice[1], ","), nil
+}
+
+// StructToSliceLine returns header and rows
+func (o OrganizationAccountResource) StructToSliceLine() ([]string, error) {
+	b, err := csvutil.Marshal([]OrganizationAccountResource{o})
+	if err != nil {
+		return nil, err
+	}
+	split := strings.Split(string(b), "\n")
+
+	return split, nil
+}
+
+/*
+	ORGANIZATIONAL UNIT
+*/
+// GetResource returns resource type
+func (o OrganizationUnitResource) GetResource() string {
+	return *o.ResourceType
+}
+
+// GetHeaders returns headers
+func (o OrganizationUnitResource) GetHeaders() ([]string, error) {
+	strSlice, err := o.StructToSliceLine()
+	if err != nil {
+		return nil, err
+	}
+
+	return strings.Split(strSlice[0], ","), nil
+}
+
+// TransferToCSV change struct to CSV
+func (o OrganizationUnitResource) TransferToCSV() ([]string, error) {
+	strSlice, err := o.StructToSliceLine()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(strSlice) <= 1 {
+		return nil, nil
+	}
+
+	return strings.Split(strSlice[1], ","), nil
+}
+
+// StructToSliceLine returns header and rows
+func (o OrganizationUnitResource) StructToSliceLine() ([]string, error) {
+	b, err := csvutil.Marshal([]OrganizationUnitResource{o})
+	if err != nil {
+		return nil, err
+	}
+	split := strings.Split(string(b), "\n")
+
+	return split, nil
+}
+
+/*
+	ORGANIZATION POLICY
+*/
+// GetResource returns resource type
+func (o OrganizationPolicyResource) GetResource() string {
+	return *o.ResourceType
+}
+
+// GetHeaders returns headers
+func (o OrganizationPolicyResource) GetHeaders() ([]string, error) {
+	strSlice, err := o.StructToSliceLine()
+	if err != nil {
+		return nil, err
+	}
+
+	return strings.Split(strSlice[0], ","), nil
+}
+
+// TransferToCSV change struct to CSV
+func (o OrganizationPolicyResource) TransferToCSV() ([]string, error) {
+	strSlice, err := o.StructToSliceLine()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(strSlice) <= 1 {
+		return nil, nil
+	}
+
+	return strings.Split(strSlice[1], ","), nil
+}
+
+// StructToSliceLine returns header and rows
+func (o OrganizationPolicyResource) StructToSliceLine() ([]string, error) {
+	b, err := csvutil.Marshal([]OrganizationPolicyResource{o})
+	if err != nil {
+		return nil, err
+	}
+	split := strings.Split(string(b), "\n")
+
+	return split, nil
+}
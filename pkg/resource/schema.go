@@ -35,39 +35,107 @@ type Resources struct {
 
 // EC2 Resource columns
 type EC2Resource struct {
-	ResourceType       *string    `json:"resource_type,omitempty"`
-	InstanceStatus     *string    `json:"instance_status,omitempty"`
-	AccountAlias       *string    `json:"account_alias,omitempty"`
-	Name               *string    `json:"name,omitempty"`
-	InstanceID         *string    `json:"instance_id,omitempty"`
-	InstanceType       *string    `json:"instance_type,omitempty"`
-	AvailabilityZone   *string    `json:"availability_zone,omitempty"`
-	RegionName         *string    `json:"region_name,omitempty"`
-	SecurityGroupNames *string    `json:"security_group_names,omitempty"`
-	SecurityGroupIDs   *string    `json:"security_group_ids,omitempty"`
-	SubnetID           *string    `json:"subnet_id,omitempty"`
-	PublicIP           *string    `json:"public_ip,omitempty"`
-	PrivateIPs         *string    `json:"private_ips,omitempty"`
-	ImageID            *string    `json:"image_id,omitempty"`
-	VpcID              *string    `json:"vpc_id,omitempty"`
-	KeyName            *string    `json:"key_name,omitempty"`
-	LaunchTime         *time.Time `json:"launch_time,omitempty"`
+	ResourceType         *string    `json:"resource_type,omitempty"`
+	InstanceStatus       *string    `json:"instance_status,omitempty"`
+	AccountAlias         *string    `json:"account_alias,omitempty"`
+	Name                 *string    `json:"name,omitempty"`
+	InstanceID           *string    `json:"instance_id,omitempty"`
+	InstanceType         *string    `json:"instance_type,omitempty"`
+	AvailabilityZone     *string    `json:"availability_zone,omitempty"`
+	RegionName           *string    `json:"region_name,omitempty"`
+	SecurityGroupNames   *string    `json:"security_group_names,omitempty"`
+	SecurityGroupIDs     *string    `json:"security_group_ids,omitempty"`
+	SubnetID             *string    `json:"subnet_id,omitempty"`
+	PublicIP             *string    `json:"public_ip,omitempty"`
+	PrivateIPs           *string    `json:"private_ips,omitempty"`
+	ImageID              *string    `json:"image_id,omitempty"`
+	VpcID                *string    `json:"vpc_id,omitempty"`
+	KeyName              *string    `json:"key_name,omitempty"`
+	IAMInstanceProfile   *string    `json:"iam_instance_profile,omitempty"`
+	MetadataHTTPTokens   *string    `json:"metadata_http_tokens,omitempty"`
+	MetadataHTTPEndpoint *string    `json:"metadata_http_endpoint,omitempty"`
+	UnencryptedVolumes   *string    `json:"unencrypted_volumes,omitempty"`
+	LaunchTime           *time.Time `json:"launch_time,omitempty"`
+	StoppedAt            *time.Time `json:"stopped_at,omitempty"`
+	Tags                 Tags       `json:"tags,omitempty"`
 
 	//OwnerID            *string    `json:"owner_id,omitempty"`
 	//IPv6s              *string    `json:"ipv6,omitempty"`
-	//IAMInstanceProfile *string    `json:"iam_instance_profile,omitempty"`
+}
+
+// Secret Resource columns. A secret found in user data or environment variables
+type SecretResource struct {
+	ResourceType *string `json:"resource_type,omitempty"`
+	Source       *string `json:"source,omitempty"`
+	ResourceID   *string `json:"resource_id,omitempty"`
+	Location     *string `json:"location,omitempty"`
+	Detector     *string `json:"detector,omitempty"`
+	Secret       *string `json:"secret,omitempty"`
+	Region       *string `json:"region,omitempty"`
+}
+
+// Key Pair Resource columns
+type KeyPairResource struct {
+	ResourceType *string `json:"resource_type,omitempty"`
+	KeyName      *string `json:"key_name,omitempty"`
+	KeyPairID    *string `json:"key_pair_id,omitempty"`
+	Fingerprint  *string `json:"fingerprint,omitempty"`
+	Region       *string `json:"region,omitempty"`
+	Instances    *int    `json:"instances,omitempty"`
+	Tags         Tags    `json:"tags,omitempty"`
 }
 
 // Security Group Resource columns
 type SGResource struct {
-	ResourceType  *string `json:"resource_type,omitempty"`
-	Name          *string `json:"name,omitempty"`
-	ID            *string `json:"id,omitempty"`
-	VpcID         *string `json:"vpc_id,omitempty"`
-	Owner         *string `json:"owner,omitempty"`
-	InboundCount  *int    `json:"inbound_count,omitempty"`
-	OutboundCount *int    `json:"outbound_count,omitempty"`
-	Description   *string `json:"description,omitempty"`
+	ResourceType       *string `json:"resource_type,omitempty"`
+	Name               *string `json:"name,omitempty"`
+	ID                 *string `json:"id,omitempty"`
+	VpcID              *string `json:"vpc_id,omitempty"`
+	Owner              *string `json:"owner,omitempty"`
+	InboundCount       *int    `json:"inbound_count,omitempty"`
+	OutboundCount      *int    `json:"outbound_count,omitempty"`
+	InboundTotalCount  *int    `json:"inbound_total_count,omitempty"`
+	OutboundTotalCount *int    `json:"outbound_total_count,omitempty"`
+	Default            *bool   `json:"default,omitempty"`
+	Usage              *string `json:"usage,omitempty"`
+	AttachmentCount    *int    `json:"attachment_count,omitempty"`
+	AttachedTo         *string `json:"attached_to,omitempty"`
+	ReferencedBy       *string `json:"referenced_by,omitempty"`
+	Description        *string `json:"description,omitempty"`
+	Tags               Tags    `json:"tags,omitempty"`
+}
+
+// Security Group Rule Resource columns
+type SGRuleResource struct {
+	ResourceType   *string `json:"resource_type,omitempty"`
+	GroupID        *string `json:"group_id,omitempty"`
+	GroupName      *string `json:"group_name,omitempty"`
+	VpcID          *string `json:"vpc_id,omitempty"`
+	Direction      *string `json:"direction,omitempty"`
+	Protocol       *string `json:"protocol,omitempty"`
+	FromPort       *int    `json:"from_port,omitempty"`
+	ToPort         *int    `json:"to_port,omitempty"`
+	PortRange      *string `json:"port_range,omitempty"`
+	SourceType     *string `json:"source_type,omitempty"`
+	Source         *string `json:"source,omitempty"`
+	WorldOpen      *bool   `json:"world_open,omitempty"`
+	SensitivePorts *string `json:"sensitive_ports,omitempty"`
+	Description    *string `json:"description,omitempty"`
+}
+
+// Internet exposure columns
+type ExposureResource struct {
+	ResourceType   *string `json:"resource_type,omitempty"`
+	TargetType     *string `json:"target_type,omitempty"`
+	TargetID       *string `json:"target_id,omitempty"`
+	Name           *string `json:"name,omitempty"`
+	Region         *string `json:"region,omitempty"`
+	VpcID          *string `json:"vpc_id,omitempty"`
+	PublicAddress  *string `json:"public_address,omitempty"`
+	Subnets        *string `json:"subnets,omitempty"`
+	SecurityGroups *string `json:"security_groups,omitempty"`
+	ExposedPorts   *string `json:"exposed_ports,omitempty"`
+	SensitivePorts *string `json:"sensitive_ports,omitempty"`
 }
 
 // Route53 Resource columns
@@ -80,46 +148,196 @@ type Route53Resource struct {
 	TTL          *int64  `json:"ttl,omitempty"`
 }
 
-type S3Resource struct {
+// Dangling DNS record columns
+type DanglingDNSResource struct {
+	ResourceType *string `json:"resource_type,omitempty"`
+	Name         *string `json:"name,omitempty"`
+	Type         *string `json:"type,omitempty"`
+	Target       *string `json:"target,omitempty"`
+	TargetType   *string `json:"target_type,omitempty"`
+	TargetRegion *string `json:"target_region,omitempty"`
+	Status       *string `json:"status,omitempty"`
+}
+
+// Elastic IP Resource columns
+type EIPResource struct {
+	ResourceType       *string `json:"resource_type,omitempty"`
+	AllocationID       *string `json:"allocation_id,omitempty"`
+	PublicIP           *string `json:"public_ip,omitempty"`
+	Region             *string `json:"region,omitempty"`
+	Domain             *string `json:"domain,omitempty"`
+	AssociationID      *string `json:"association_id,omitempty"`
+	InstanceID         *string `json:"instance_id,omitempty"`
+	NetworkInterfaceID *string `json:"network_interface_id,omitempty"`
+	Tags               Tags    `json:"tags,omitempty"`
+}
+
+// Load Balancer Resource columns
+type LoadBalancerResource struct {
+	ResourceType      *string    `json:"resource_type,omitempty"`
+	Name              *string    `json:"name,omitempty"`
+	Type              *string    `json:"type,omitempty"`
+	Scheme            *string    `json:"scheme,omitempty"`
+	DNSName           *string    `json:"dns_name,omitempty"`
+	Region            *string    `json:"region,omitempty"`
+	VpcID             *string    `json:"vpc_id,omitempty"`
+	State             *string    `json:"state,omitempty"`
+	AccessLogsEnabled *bool      `json:"access_logs_enabled,omitempty"`
+	AccessLogsBucket  *string    `json:"access_logs_bucket,omitempty"`
+	Created           *time.Time `json:"created,omitempty"`
+}
+
+// CloudTrail trail columns
+type CloudTrailResource struct {
+	ResourceType                 *string    `json:"resource_type,omitempty"`
+	Name                         *string    `json:"name,omitempty"`
+	TrailARN                     *string    `json:"trail_arn,omitempty"`
+	Region                       *string    `json:"region,omitempty"`
+	MultiRegion                  *bool      `json:"multi_region,omitempty"`
+	OrganizationTrail            *bool      `json:"organization_trail,omitempty"`
+	Logging                      *bool      `json:"logging,omitempty"`
+	LogFileValidation            *bool      `json:"log_file_validation,omitempty"`
+	S3Bucket                     *string    `json:"s3_bucket,omitempty"`
+	CloudWatchLogsLogGroup       *string    `json:"cloudwatch_logs_log_group,omitempty"`
+	LatestCloudWatchLogsDelivery *time.Time `json:"latest_cloudwatch_logs_delivery,omitempty"`
+	KmsKeyID                     *string    `json:"kms_key_id,omitempty"`
+}
+
+// VPC columns
+type VPCResource struct {
+	ResourceType    *string `json:"resource_type,omitempty"`
+	VpcID           *string `json:"vpc_id,omitempty"`
+	Name            *string `json:"name,omitempty"`
+	CidrBlock       *string `json:"cidr_block,omitempty"`
+	Default         *bool   `json:"default,omitempty"`
+	Region          *string `json:"region,omitempty"`
+	FlowLogsEnabled *bool   `json:"flow_logs_enabled,omitempty"`
+	FlowLogs        *string `json:"flow_logs,omitempty"`
+}
+
+// CloudFront distribution columns
+type CloudFrontResource struct {
 	ResourceType   *string    `json:"resource_type,omitempty"`
-	Bucket         *string    `json:"bucket,omitempty"`
-	Region         *string    `json:"region,omitempty"`
-	LoggingEnabled *bool      `json:"logging_enabled,omitempty"`
-	LoggingBucket  *string    `json:"logging_bucket,omitempty"`
-	Created        *time.Time `json:"created,omitempty"`
-	Policy         *string    `json:"policy,omitempty"`
+	DistributionID *string    `json:"distribution_id,omitempty"`
+	DomainName     *string    `json:"domain_name,omitempty"`
+	Aliases        *string    `json:"aliases,omitempty"`
+	Origins        *string    `json:"origins,omitempty"`
+	Status         *string    `json:"status,omitempty"`
+	Enabled        *bool      `json:"enabled,omitempty"`
+	LastModified   *time.Time `json:"last_modified,omitempty"`
+}
+
+// Elastic Beanstalk environment columns
+type BeanstalkResource struct {
+	ResourceType    *string    `json:"resource_type,omitempty"`
+	ApplicationName *string    `json:"application_name,omitempty"`
+	EnvironmentName *string    `json:"environment_name,omitempty"`
+	EnvironmentID   *string    `json:"environment_id,omitempty"`
+	Region          *string    `json:"region,omitempty"`
+	CNAME           *string    `json:"cname,omitempty"`
+	EndpointURL     *string    `json:"endpoint_url,omitempty"`
+	Status          *string    `json:"status,omitempty"`
+	Health          *string    `json:"health,omitempty"`
+	Created         *time.Time `json:"created,omitempty"`
+}
+
+type S3Resource struct {
+	ResourceType             *string    `json:"resource_type,omitempty"`
+	Bucket                   *string    `json:"bucket,omitempty"`
+	Region                   *string    `json:"region,omitempty"`
+	Access                   *string    `json:"access,omitempty"`
+	AccountBlockPublicAccess *bool      `json:"account_block_public_access,omitempty"`
+	BucketBlockPublicAccess  *bool      `json:"bucket_block_public_access,omitempty"`
+	PublicACLGrants          *string    `json:"public_acl_grants,omitempty"`
+	PublicPolicy             *bool      `json:"public_policy,omitempty"`
+	CrossAccounts            *string    `json:"cross_accounts,omitempty"`
+	Encryption               *string    `json:"encryption,omitempty"`
+	KmsKeyID                 *string    `json:"kms_key_id,omitempty"`
+	Versioning               *string    `json:"versioning,omitempty"`
+	MFADelete                *bool      `json:"mfa_delete,omitempty"`
+	ObjectLock               *bool      `json:"object_lock,omitempty"`
+	TLSEnforced              *bool      `json:"tls_enforced,omitempty"`
+	LoggingEnabled           *bool      `json:"logging_enabled,omitempty"`
+	LoggingBucket            *string    `json:"logging_bucket,omitempty"`
+	CloudTrailBucket         *bool      `json:"cloudtrail_bucket,omitempty"`
+	Created                  *time.Time `json:"created,omitempty"`
+	Tags                     Tags       `json:"tags,omitempty"`
+	TagsUnknown              *bool      `json:"tags_unknown,omitempty" csv:"-"`
+	Policy                   *string    `json:"policy,omitempty"`
 }
 
 type RDSResource struct {
-	ResourceType     *string    `json:"resource_type,omitempty"`
-	RDSIdentifier    *string    `json:"rds_identifier,omitempty"`
-	Role             *string    `json:"role,omitempty"`
-	Engine           *string    `json:"engine,omitempty"`
-	EngineVersion    *string    `json:"engine_version,omitempty"`
-	Region           *string    `json:"region,omitempty"`
-	AvailabilityZone *string    `json:"availability_zone,omitempty"`
-	Size             *string    `json:"size,omitempty"`
-	Status           *string    `json:"status,omitempty"`
-	VPC              *string    `json:"vpc,omitempty"`
-	StorageType      *string    `json:"storage_type,omitempty"`
-	SecurityGroup    *string    `json:"security_group,omitempty"`
-	DBSubnet         *string    `json:"db_subnet,omitempty"`
-	ParameterGroup   *string    `json:"parameter_group,omitempty"`
-	OptionGroup      *string    `json:"option_group,omitempty"`
-	Created          *time.Time `json:"created,omitempty"`
+	ResourceType            *string    `json:"resource_type,omitempty"`
+	RDSIdentifier           *string    `json:"rds_identifier,omitempty"`
+	Cluster                 *string    `json:"cluster,omitempty"`
+	Role                    *string    `json:"role,omitempty"`
+	Engine                  *string    `json:"engine,omitempty"`
+	EngineVersion           *string    `json:"engine_version,omitempty"`
+	EndOfSupport            *time.Time `json:"end_of_support,omitempty"`
+	Region                  *string    `json:"region,omitempty"`
+	AvailabilityZone        *string    `json:"availability_zone,omitempty"`
+	Size                    *string    `json:"size,omitempty"`
+	Status                  *string    `json:"status,omitempty"`
+	VPC                     *string    `json:"vpc,omitempty"`
+	StorageType             *string    `json:"storage_type,omitempty"`
+	SecurityGroup           *string    `json:"security_group,omitempty"`
+	DBSubnet                *string    `json:"db_subnet,omitempty"`
+	ParameterGroup          *string    `json:"parameter_group,omitempty"`
+	OptionGroup             *string    `json:"option_group,omitempty"`
+	StorageEncrypted        *bool      `json:"storage_encrypted,omitempty"`
+	PubliclyAccessible      *bool      `json:"publicly_accessible,omitempty"`
+	BackupRetentionPeriod   *int       `json:"backup_retention_period,omitempty"`
+	DeletionProtection      *bool      `json:"deletion_protection,omitempty"`
+	MultiAZ                 *bool      `json:"multi_az,omitempty"`
+	IAMAuthEnabled          *bool      `json:"iam_auth_enabled,omitempty"`
+	AutoMinorVersionUpgrade *bool      `json:"auto_minor_version_upgrade,omitempty"`
+	Created                 *time.Time `json:"created,omitempty"`
+	Tags                    Tags       `json:"tags,omitempty"`
 }
 
 type IAMUserResource struct {
-	ResourceType      *string    `json:"resource_type,omitempty"`
-	UserName          *string    `json:"user_name,omitempty"`
-	AccessKeyAge      *string    `json:"access_key_age,omitempty"`
-	PasswordAge       *string    `json:"password_age,omitempty"`
-	UserLastActivity  *string    `json:"user_last_activity,omitempty"`
-	MFA               *string    `json:"mfa,omitempty"`
-	GroupCount        *int       `json:"group_count,omitempty"`
-	ConsoleLastLogin  *time.Time `json:"console_last_login,omitempty"`
-	AccessKeyLastUsed *time.Time `json:"access_key_last_usec,omitempty"`
-	UserCreated       *time.Time `json:"created,omitempty"`
+	ResourceType              *string    `json:"resource_type,omitempty"`
+	UserName                  *string    `json:"user_name,omitempty"`
+	AccessKeyAge              *int       `json:"access_key_age,omitempty"`
+	PasswordEnabled           *bool      `json:"password_enabled,omitempty"`
+	PasswordAge               *int       `json:"password_age,omitempty"`
+	PasswordLastChanged       *time.Time `json:"password_last_changed,omitempty"`
+	UserLastActivity          *time.Time `json:"user_last_activity,omitempty"`
+	MFA                       *string    `json:"mfa,omitempty"`
+	MFAActive                 *bool      `json:"mfa_active,omitempty"`
+	GroupCount                *int       `json:"group_count,omitempty"`
+	AccessKey1Active          *bool      `json:"access_key_1_active,omitempty"`
+	AccessKey1Age             *int       `json:"access_key_1_age,omitempty"`
+	AccessKey1LastUsedService *string    `json:"access_key_1_last_used_service,omitempty"`
+	AccessKey2Active          *bool      `json:"access_key_2_active,omitempty"`
+	AccessKey2Age             *int       `json:"access_key_2_age,omitempty"`
+	AccessKey2LastUsedService *string    `json:"access_key_2_last_used_service,omitempty"`
+	ConsoleLastLogin          *time.Time `json:"console_last_login,omitempty"`
+	AccessKeyLastUsed         *time.Time `json:"access_key_last_usec,omitempty"`
+	UserCreated               *time.Time `json:"created,omitempty"`
+	Tags                      Tags       `json:"tags,omitempty"`
+}
+
+type IAMAccessKeyResource struct {
+	ResourceType    *string    `json:"resource_type,omitempty"`
+	AccessKeyID     *string    `json:"access_key_id,omitempty"`
+	UserName        *string    `json:"user_name,omitempty"`
+	Status          *string    `json:"status,omitempty"`
+	AgeDays         *int       `json:"age_days,omitempty"`
+	LastUsed        *time.Time `json:"last_used,omitempty"`
+	LastUsedService *string    `json:"last_used_service,omitempty"`
+	LastUsedRegion  *string    `json:"last_used_region,omitempty"`
+	Created         *time.Time `json:"created,omitempty"`
+}
+
+type IAMEscalationResource struct {
+	ResourceType    *string `json:"resource_type,omitempty"`
+	PrincipalType   *string `json:"principal_type,omitempty"`
+	PrincipalName   *string `json:"principal_name,omitempty"`
+	AdminEquivalent *bool   `json:"admin_equivalent,omitempty"`
+	Primitives      *string `json:"primitives,omitempty"`
+	PathCount       *int    `json:"path_count,omitempty"`
+	Paths           *string `json:"paths,omitempty"`
 }
 
 type IAMGroupResource struct {
@@ -131,8 +349,172 @@ type IAMGroupResource struct {
 }
 
 type IAMRoleResource struct {
+	ResourceType        *string    `json:"resource_type,omitempty"`
+	RoleName            *string    `json:"role_name,omitempty"`
+	TrustedEntities     *string    `json:"trusted_entities,omitempty"`
+	TrustClassification *string    `json:"trust_classification,omitempty"`
+	TrustedAccounts     *string    `json:"trusted_accounts,omitempty"`
+	ExternalIDRequired  *bool      `json:"external_id_required,omitempty"`
+	TrustConditions     *string    `json:"trust_conditions,omitempty"`
+	AttachedPolicies    *string    `json:"attached_policies,omitempty"`
+	InlinePolicies      *string    `json:"inline_policies,omitempty"`
+	RoleLastActivity    *time.Time `json:"role_last_activity,omitempty"`
+	Created             *time.Time `json:"created,omitempty"`
+	Tags                Tags       `json:"tags,omitempty"`
+}
+
+type IAMPolicyResource struct {
 	ResourceType     *string    `json:"resource_type,omitempty"`
-	RoleName         *string    `json:"role_name,omitempty"`
-	TrustedEntities  *string    `json:"trusted_entities,omitempty"`
-	RoleLastActivity *time.Time `json:"role_last_activity,omitempty"`
+	PolicyName       *string    `json:"policy_name,omitempty"`
+	PolicyArn        *string    `json:"policy_arn,omitempty"`
+	PolicyID         *string    `json:"policy_id,omitempty"`
+	PolicyType       *string    `json:"policy_type,omitempty"`
+	DefaultVersion   *string    `json:"default_version,omitempty"`
+	AttachmentCount  *int       `json:"attachment_count,omitempty"`
+	AttachedEntities *string    `json:"attached_entities,omitempty"`
+	WildcardAction   *bool      `json:"wildcard_action,omitempty"`
+	WildcardResource *bool      `json:"wildcard_resource,omitempty"`
+	Created          *time.Time `json:"created,omitempty"`
+	Updated          *time.Time `json:"updated,omitempty"`
+}
+
+type EFSResource struct {
+	ResourceType       *string    `json:"resource_type,omitempty"`
+	Name               *string    `json:"name,omitempty"`
+	FileSystemID       *string    `json:"file_system_id,omitempty"`
+	Region             *string    `json:"region,omitempty"`
+	LifeCycleState     *string    `json:"life_cycle_state,omitempty"`
+	PerformanceMode    *string    `json:"performance_mode,omitempty"`
+	ThroughputMode     *string    `json:"throughput_mode,omitempty"`
+	Encrypted          *bool      `json:"encrypted,omitempty"`
+	KmsKeyID           *string    `json:"kms_key_id,omitempty"`
+	SizeInBytes        *int64     `json:"size_in_bytes,omitempty"`
+	MountTargetCount   *int       `json:"mount_target_count,omitempty"`
+	MountTargetSubnets *string    `json:"mount_target_subnets,omitempty"`
+	AccessPointCount   *int       `json:"access_point_count,omitempty"`
+	BackupPolicy       *string    `json:"backup_policy,omitempty"`
+	Created            *time.Time `json:"created,omitempty"`
+	Tags               Tags       `json:"tags,omitempty"`
+}
+
+type FSxResource struct {
+	ResourceType        *string    `json:"resource_type,omitempty"`
+	FileSystemID        *string    `json:"file_system_id,omitempty"`
+	FileSystemType      *string    `json:"file_system_type,omitempty"`
+	Region              *string    `json:"region,omitempty"`
+	Lifecycle           *string    `json:"lifecycle,omitempty"`
+	StorageType         *string    `json:"storage_type,omitempty"`
+	StorageCapacity     *int       `json:"storage_capacity,omitempty"`
+	KmsKeyID            *string    `json:"kms_key_id,omitempty"`
+	VpcID               *string    `json:"vpc_id,omitempty"`
+	SubnetIDs           *string    `json:"subnet_ids,omitempty"`
+	BackupRetentionDays *int       `json:"backup_retention_days,omitempty"`
+	Created             *time.Time `json:"created,omitempty"`
+	Tags                Tags       `json:"tags,omitempty"`
+}
+
+type KinesisResource struct {
+	ResourceType   *string    `json:"resource_type,omitempty"`
+	StreamName     *string    `json:"stream_name,omitempty"`
+	Region         *string    `json:"region,omitempty"`
+	StreamStatus   *string    `json:"stream_status,omitempty"`
+	StreamMode     *string    `json:"stream_mode,omitempty"`
+	EncryptionType *string    `json:"encryption_type,omitempty"`
+	KmsKeyID       *string    `json:"kms_key_id,omitempty"`
+	RetentionHours *int       `json:"retention_hours,omitempty"`
+	OpenShardCount *int       `json:"open_shard_count,omitempty"`
+	Created        *time.Time `json:"created,omitempty"`
+}
+
+type MSKResource struct {
+	ResourceType          *string    `json:"resource_type,omitempty"`
+	ClusterName           *string    `json:"cluster_name,omitempty"`
+	Region                *string    `json:"region,omitempty"`
+	State                 *string    `json:"state,omitempty"`
+	KafkaVersion          *string    `json:"kafka_version,omitempty"`
+	BrokerCount           *int       `json:"broker_count,omitempty"`
+	InstanceType          *string    `json:"instance_type,omitempty"`
+	AtRestKmsKeyID        *string    `json:"at_rest_kms_key_id,omitempty"`
+	InTransitClientBroker *string    `json:"in_transit_client_broker,omitempty"`
+	InTransitInCluster    *bool      `json:"in_transit_in_cluster,omitempty"`
+	PublicAccess          *string    `json:"public_access,omitempty"`
+	RetentionHours        *int       `json:"retention_hours,omitempty"`
+	Created               *time.Time `json:"created,omitempty"`
+	Tags                  Tags       `json:"tags,omitempty"`
+}
+
+type OrganizationAccountResource struct {
+	ResourceType *string    `json:"resource_type,omitempty"`
+	AccountID    *string    `json:"account_id,omitempty"`
+	Name         *string    `json:"name,omitempty"`
+	Email        *string    `json:"email,omitempty"`
+	Status       *string    `json:"status,omitempty"`
+	JoinedMethod *string    `json:"joined_method,omitempty"`
+	ParentID     *string    `json:"parent_id,omitempty"`
+	ParentName   *string    `json:"parent_name,omitempty"`
+	SCPs         *string    `json:"scps,omitempty"`
+	Joined       *time.Time `json:"joined,omitempty"`
+}
+
+type OrganizationUnitResource struct {
+	ResourceType *string `json:"resource_type,omitempty"`
+	OUID         *string `json:"ou_id,omitempty"`
+	Name         *string `json:"name,omitempty"`
+	ParentID     *string `json:"parent_id,omitempty"`
+	AccountCount *int    `json:"account_count,omitempty"`
+	SCPs         *string `json:"scps,omitempty"`
+}
+
+type OrganizationPolicyResource struct {
+	ResourceType *string `json:"resource_type,omitempty"`
+	PolicyID     *string `json:"policy_id,omitempty"`
+	Name         *string `json:"name,omitempty"`
+	AWSManaged   *bool   `json:"aws_managed,omitempty"`
+	Targets      *string `json:"targets,omitempty"`
+	Description  *string `json:"description,omitempty"`
+}
+
+type SSOPermissionSetResource struct {
+	ResourceType            *string    `json:"resource_type,omitempty"`
+	Name                    *string    `json:"name,omitempty"`
+	PermissionSetArn        *string    `json:"permission_set_arn,omitempty"`
+	Region                  *string    `json:"region,omitempty"`
+	SessionDuration         *string    `json:"session_duration,omitempty"`
+	ManagedPolicies         *string    `json:"managed_policies,omitempty"`
+	CustomerManagedPolicies *string    `json:"customer_managed_policies,omitempty"`
+	InlinePolicy            *bool      `json:"inline_policy,omitempty"`
+	AccountCount            *int       `json:"account_count,omitempty"`
+	Accounts                *string    `json:"accounts,omitempty"`
+	Description             *string    `json:"description,omitempty"`
+	Created                 *time.Time `json:"created,omitempty"`
+}
+
+type SSOAccountAssignmentResource struct {
+	ResourceType  *string `json:"resource_type,omitempty"`
+	PermissionSet *string `json:"permission_set,omitempty"`
+	AccountID     *string `json:"account_id,omitempty"`
+	PrincipalType *string `json:"principal_type,omitempty"`
+	PrincipalID   *string `json:"principal_id,omitempty"`
+	PrincipalName *string `json:"principal_name,omitempty"`
+	Region        *string `json:"region,omitempty"`
+}
+
+type AccountResource struct {
+	ResourceType            *string `json:"resource_type,omitempty"`
+	AccountID               *string `json:"account_id,omitempty"`
+	AccountAlias            *string `json:"account_alias,omitempty"`
+	RootMFAEnabled          *bool   `json:"root_mfa_enabled,omitempty"`
+	RootAccessKeysPresent   *bool   `json:"root_access_keys_present,omitempty"`
+	PasswordPolicy          *bool   `json:"password_policy,omitempty"`
+	MinimumPasswordLength   *int    `json:"minimum_password_length,omitempty"`
+	RequireSymbols          *bool   `json:"require_symbols,omitempty"`
+	RequireNumbers          *bool   `json:"require_numbers,omitempty"`
+	RequireUppercase        *bool   `json:"require_uppercase,omitempty"`
+	RequireLowercase        *bool   `json:"require_lowercase,omitempty"`
+	PasswordReusePrevention *int    `json:"password_reuse_prevention,omitempty"`
+	MaxPasswordAge          *int    `json:"max_password_age,omitempty"`
+	BillingContact          *string `json:"billing_contact,omitempty"`
+	OperationsContact       *string `json:"operations_contact,omitempty"`
+	SecurityContact         *string `json:"security_contact,omitempty"`
+	MultiRegionTrail        *bool   `json:"multi_region_trail,omitempty"`
 }
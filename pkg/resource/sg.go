@@ -61,3 +61,46 @@ func (s SGResource) StructToSliceLine() ([]string, error) {
 
 	return split, nil
 }
+
+/*
+	SECURITY GROUP RULE
+*/
+// GetResource returns resource type
+func (s SGRuleResource) GetResource() string {
+	return *s.ResourceType
+}
+
+// GetHeaders returns headers
+func (s SGRuleResource) GetHeaders() ([]string, error) {
+	strSlice, err := s.StructToSliceLine()
+	if err != nil {
+		return nil, err
+	}
+
+	return strings.Split(strSlice[0], ","), nil
+}
+
+// TransferToCSV change struct to CSV
+func (s SGRuleResource) TransferToCSV() ([]string, error) {
+	strSlice, err := s.StructToSliceLine()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(strSlice) <= 1 {
+		return nil, nil
+	}
+
+	return strings.Split(strSlice[1], ","), nil
+}
+
+// StructToSliceLine returns header and rows
+func (s SGRuleResource) StructToSliceLine() ([]string, error) {
+	b, err := csvutil.Marshal([]SGRuleResource{s})
+	if err != nil {
+		return nil, err
+	}
+	split := strings.Split(string(b), "\n")
+
+	return split, nil
+}
@@ -0,0 +1,109 @@
+/*
+Copyright 2020 The redhawk Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resource
+
+import (
+	"strings"
+
+	"github.com/jszwec/csvutil"
+)
+
+/*
+	SSO PERMISSION SET
+*/
+// GetResource returns resource type
+func (s SSOPermissionSetResource) GetResource() string {
+	return *s.ResourceType
+}
+
+// GetHeaders returns headers
+func (s SSOPermissionSetResource) GetHeaders() ([]string, error) {
+	strSlice, err := s.StructToSliceLine()
+	if err != nil {
+		return nil, err
+	}
+
+	return strings.Split(strSlice[0], ","), nil
+}
+
+// TransferToCSV change struct to CSV
+func (s SSOPermissionSetResource) TransferToCSV() ([]string, error) {
+	strSlice, err := s.StructToSliceLine()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(strSlice) <= 1 {
+		return nil, nil
+	}
+
+	return strings.Split(strSlice[1], ","), nil
+}
+
+// StructToSliceLine returns header and rows
+func (s SSOPermissionSetResource) StructToSliceLine() ([]string, error) {
+	b, err := csvutil.Marshal([]SSOPermissionSetResource{s})
+	if err != nil {
+		return nil, err
+	}
+	split := strings.Split(string(b), "\n")
+
+	return split, nil
+}
+
+/*
+	SSO ACCOUNT ASSIGNMENT
+*/
+// GetResource returns resource type
+func (s SSOAccountAssignmentResource) GetResource() string {
+	return *s.ResourceType
+}
+
+// GetHeaders returns headers
+func (s SSOAccountAssignmentResource) GetHeaders() ([]string, error) {
+	strSlice, err := s.StructToSliceLine()
+	if err != nil {
+		return nil, err
+	}
+
+	return strings.Split(strSlice[0], ","), nil
+}
+
+// TransferToCSV change struct to CSV
+func (s SSOAccountAssignmentResource) TransferToCSV() ([]string, error) {
+	strSlice, err := s.StructToSliceLine()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(strSlice) <= 1 {
+		return nil, nil
+	}
+
+	return strings.Split(strSlice[1], ","), nil
+}
+
+// StructToSliceLine returns header and rows
+func (s SSOAccountAssignmentResource) StructToSliceLine() ([]string, error) {
+	b, err := csvutil.Marshal([]SSOAccountAssignmentResource{s})
+	if err != nil {
+		return nil, err
+	}
+	split := strings.Split(string(b), "\n")
+
+	return split, nil
+}
@@ -0,0 +1,51 @@
+/*
+Copyright 2020 The redhawk Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resource
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/DevopsArtFactory/redhawk/pkg/constants"
+)
+
+// Tags are tags of a resource by key
+type Tags map[string]string
+
+// MarshalText writes tags as sorted `key=value` pairs for csv
+func (t Tags) MarshalText() ([]byte, error) {
+	var pairs []string
+	for k, v := range t {
+		pairs = append(pairs, fmt.Sprintf("%s=%s", k, v))
+	}
+	sort.Strings(pairs)
+
+	return []byte(strings.Join(pairs, constants.DefaultDelimiter)), nil
+}
+
+// MarshalJSON writes tags as an object so that audit rules can read each tag
+func (t Tags) MarshalJSON() ([]byte, error) {
+	return json.Marshal(map[string]string(t))
+}
+
+// String returns tags for standard out
+func (t Tags) String() string {
+	b, _ := t.MarshalText()
+	return string(b)
+}
@@ -17,17 +17,21 @@ limitations under the License.
 package runner
 
 import (
+	"fmt"
 	"io"
 	"time"
 
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
 	"github.com/sirupsen/logrus"
 
+	"github.com/DevopsArtFactory/redhawk/pkg/audit"
 	"github.com/DevopsArtFactory/redhawk/pkg/builder"
 	"github.com/DevopsArtFactory/redhawk/pkg/client"
 	"github.com/DevopsArtFactory/redhawk/pkg/constants"
 	"github.com/DevopsArtFactory/redhawk/pkg/printer"
 	"github.com/DevopsArtFactory/redhawk/pkg/provider"
 	"github.com/DevopsArtFactory/redhawk/pkg/resource"
+	"github.com/DevopsArtFactory/redhawk/pkg/schema"
 	"github.com/DevopsArtFactory/redhawk/pkg/tools"
 )
 
@@ -38,10 +42,12 @@ type Runner struct {
 }
 
 type Record struct {
-	Error    error
-	Resource string
-	Region   string
-	Data     []resource.Resource
+	Error     error
+	Account   string
+	AccountID string
+	Resource  string
+	Region    string
+	Data      []resource.Resource
 }
 
 func New() *Runner {
@@ -53,6 +59,207 @@ func (r Runner) List(out io.Writer) error {
 	t := time.Now()
 	logrus.Info("start scanning resources")
 
+	accounts, err := r.getAccounts()
+	if err != nil {
+		return err
+	}
+
+	records, err := r.scanAll(accounts, false)
+	if err != nil {
+		return err
+	}
+
+	// Accounts may have the same name, so data is grouped by ID
+	result := map[string][]resource.Resource{}
+	for _, record := range records {
+		result[record.AccountID] = append(result[record.AccountID], record.Data...)
+	}
+
+	logrus.Debugf("Check format validation: %s", r.Builder.Flags.Output)
+	if err := tools.CheckValidFormat(r.Builder.Flags.Output); err != nil {
+		return err
+	}
+
+	logrus.Debugf("Create a printer for output: %s", r.Builder.Flags.Output)
+	printer, err := printer.SelectPrinter(r.Builder.Flags.Output)
+	if err != nil {
+		return err
+	}
+	logrus.Debug("Printer is successfully created")
+
+	for _, account := range accounts {
+		name := getAccountName(account)
+		data := result[getAccountID(account.RoleArn)]
+
+		logrus.Debugf("Set a number of data for printer: %s / %d", name, len(data))
+		pr, err := printer.SetData(r.Builder.Config.Provider, name, data)
+		if err != nil {
+			return err
+		}
+		logrus.Debug("Data setting for printer is done")
+
+		logrus.Debug("Start printer to print the result")
+		if err := pr.Print(); err != nil {
+			return err
+		}
+	}
+
+	end := time.Now()
+	logrus.Infof("Scan time: %f sec", end.Sub(t).Seconds())
+
+	return nil
+}
+
+// Audit evaluates rules over resources in AWS and prints findings
+func (r Runner) Audit(out io.Writer) error {
+	t := time.Now()
+	logrus.Info("start auditing resources")
+
+	logrus.Debugf("Check format validation: %s", r.Builder.Flags.Output)
+	if err := tools.CheckValidFormat(r.Builder.Flags.Output); err != nil {
+		return err
+	}
+
+	rules, err := audit.LoadRules(r.Builder.Config.Audit)
+	if err != nil {
+		return err
+	}
+
+	benchmark, err := audit.LoadBenchmark(r.Builder.Flags.Profile)
+	if err != nil {
+		return err
+	}
+
+	if benchmark != nil {
+		var params map[string]string
+		if r.Builder.Config.Audit != nil {
+			params = r.Builder.Config.Audit.Params
+		}
+
+		rules, err = benchmark.FilterRules(rules, params)
+		if len(r.Builder.Flags.Resources) == 0 {
+			r.addRuleResources(rules)
+		}
+	} else {
+		rules, err = audit.FilterProfile(rules, r.Builder.Flags.Profile)
+	}
+	if err != nil {
+		return err
+	}
+	engine := audit.NewEngine(rules)
+	logrus.Debugf("Enabled rules: %d", len(engine.Rules))
+
+	// Tag policies are checked with all rules only
+	var tagChecker *audit.TagChecker
+	if len(r.Builder.Config.TagPolicy) > 0 && len(r.Builder.Flags.Profile) == 0 {
+		tagChecker, err = audit.NewTagChecker(r.Builder.Config.TagPolicy)
+		if err != nil {
+			return err
+		}
+	}
+
+	waivers, err := audit.LoadWaivers(r.Builder.Flags.IgnoreFile)
+	if err != nil {
+		return err
+	}
+	logrus.Debugf("Waivers of findings: %d", len(waivers))
+
+	r.addDNSTargetResources()
+
+	accounts, err := r.getAccounts()
+	if err != nil {
+		return err
+	}
+
+	// Buckets are audited once in their own region
+	records, err := r.scanAll(accounts, true)
+	if err != nil {
+		return err
+	}
+
+	var targets []audit.Target
+	coverage := audit.Coverage{}
+	for _, record := range records {
+		if record.Error == nil {
+			coverage.Add(record.Resource, record.Region)
+		}
+
+		for _, d := range record.Data {
+			targets = append(targets, audit.Target{
+				Account:  record.Account,
+				Region:   record.Region,
+				Resource: d,
+			})
+		}
+	}
+
+	// Targets of Route53 records are looked up after all resources are scanned
+	if coverage.Covers(constants.Route53ResourceName, constants.EmptyString) {
+		dns := audit.CheckDanglingDNS(targets, audit.NewDNSInventory(targets, coverage))
+		logrus.Debugf("Route53 records pointing at AWS resources: %d", len(dns))
+		targets = append(targets, dns...)
+	}
+
+	findings, err := engine.Evaluate(targets)
+	if err != nil {
+		return err
+	}
+
+	if tagChecker != nil {
+		tagFindings, err := tagChecker.Check(targets)
+		if err != nil {
+			return err
+		}
+		logrus.Debugf("findings from tag policies: %d", len(tagFindings))
+
+		findings = append(findings, tagFindings...)
+		audit.SortFindings(findings)
+	}
+
+	report := audit.ApplyWaivers(findings, waivers, time.Now())
+	if benchmark != nil {
+		report.Benchmark = benchmark.Title
+		report.Controls = benchmark.Evaluate(rules, report, coverage)
+	}
+
+	if framework := r.Builder.Flags.Framework; len(framework) > 0 {
+		report.Framework = framework
+		report.ByControl = audit.GroupByControl(report.Findings, rules, framework)
+	}
+	if len(report.Suppressed) > 0 {
+		logrus.Infof("findings suppressed by waivers: %d", len(report.Suppressed))
+	}
+
+	logrus.Debugf("Create a printer for output: %s", r.Builder.Flags.Output)
+	printer, err := printer.SelectFindingPrinter(r.Builder.Flags.Output)
+	if err != nil {
+		return err
+	}
+
+	pr, err := printer.SetFindings(r.Builder.Config.Provider, report)
+	if err != nil {
+		return err
+	}
+
+	if err := pr.Print(); err != nil {
+		return err
+	}
+
+	end := time.Now()
+	logrus.Infof("Audit time: %f sec", end.Sub(t).Seconds())
+
+	if failOn := r.Builder.Flags.FailOn; len(failOn) > 0 {
+		if count := report.AtOrAbove(failOn); count > 0 {
+			return fmt.Errorf("%d findings are at or above severity %s", count, failOn)
+		}
+	}
+
+	return nil
+}
+
+// scanAll scans all resources of accounts and returns records which have data or are scanned without error.
+// If regionScoped is set, clients which list resources of all regions only return resources of the scanned region
+func (r *Runner) scanAll(accounts []schema.Account, regionScoped bool) ([]Record, error) {
 	var errors []error
 	ch := make(chan Record)
 	totalCount := 0
@@ -63,111 +270,191 @@ func (r Runner) List(out io.Writer) error {
 			totalCount++
 		}
 	}
-	r.TotalCount = totalCount
+	r.TotalCount = totalCount * len(accounts)
 
-	logrus.Debugf("Resource count is %d", totalCount)
+	logrus.Debugf("Resource count is %d", r.TotalCount)
 
-	// Resources based
-	for _, t := range r.Builder.Config.Resources {
-		// Create new provider
-		prov, err := provider.CreateProvider(r.Builder.Config.Provider)
+	// Create new provider
+	prov, err := provider.CreateProvider(r.Builder.Config.Provider)
+	if err != nil {
+		return nil, err
+	}
 
-		if err != nil {
-			return err
-		}
+	// Account based
+	for _, account := range accounts {
+		name := getAccountName(account)
 
-		if t.Global {
-			logrus.Debugf("scanning global resources: %s", t.Name)
-			go func(name string) {
-				re := Record{
-					Error:    nil,
-					Region:   constants.DefaultRegion,
-					Resource: name,
+		// Resources based
+		for _, t := range r.Builder.Config.Resources {
+			if t.Global {
+				logrus.Debugf("scanning global resources: %s / %s", name, t.Name)
+				go func(account schema.Account, name, resource string) {
+					ch <- scan(prov, name, account.RoleArn, constants.DefaultRegion, resource, regionScoped)
+				}(account, name, t.Name)
+			} else {
+				// Region based
+				for _, region := range r.Builder.Config.Regions {
+					logrus.Debugf("scanning regional resources: %s / %s / %s", name, region, t.Name)
+					go func(account schema.Account, name, resource, region string) {
+						ch <- scan(prov, name, account.RoleArn, region, resource, regionScoped)
+					}(account, name, t.Name, region)
 				}
-				c, err := prov.CreateClient(constants.DefaultRegion, name)
-				if err != nil {
-					re.Error = err
-				} else {
-					data, err := c.Scan()
-					re.Error = err
-					re.Data = data
-				}
-				ch <- re
-			}(t.Name)
-		} else {
-			// Region based
-			for _, region := range r.Builder.Config.Regions {
-				logrus.Debugf("scanning regional resources: %s / %s", region, t.Name)
-				go func(name, region string) {
-					re := Record{
-						Error:    nil,
-						Resource: name,
-						Region:   region,
-					}
-					c, err := prov.CreateClient(region, name)
-					if err != nil {
-						re.Error = err
-					} else {
-						data, err := c.Scan()
-						re.Error = err
-						re.Data = data
-					}
-					ch <- re
-				}(t.Name, region)
 			}
 		}
 	}
 
-	result := resource.Resources{
-		Provider: r.Builder.Config.Provider,
-	}
-
+	var records []Record
 	for i := 0; i < r.TotalCount; i++ {
 		record := <-ch
 
 		if record.Data != nil {
-			logrus.Debugf("data found: %s / %s / %s", record.Region, record.Resource, record.Data[0].GetResource())
-			result.Resources = append(result.Resources, record.Data...)
+			logrus.Debugf("data found: %s / %s / %s / %s", record.Account, record.Region, record.Resource, record.Data[0].GetResource())
 		}
 
 		if record.Error != nil {
 			errors = append(errors, record.Error)
 		}
+
+		if record.Data != nil || record.Error == nil {
+			records = append(records, record)
+		}
 	}
 	logrus.Debugf("Completed gathering all data")
 
-	logrus.Debugf("Check format validation: %s", r.Builder.Flags.Output)
-	if err := tools.CheckValidFormat(r.Builder.Flags.Output); err != nil {
-		return err
+	if len(errors) > 0 && (logrus.GetLevel() == logrus.DebugLevel && logrus.GetLevel() == logrus.TraceLevel) {
+		for _, err := range errors {
+			logrus.Error(err.Error())
+		}
 	}
 
-	logrus.Debugf("Create a printer for output: %s", r.Builder.Flags.Output)
-	printer, err := printer.SelectPrinter(r.Builder.Flags.Output)
-	if err != nil {
-		return err
+	return records, nil
+}
+
+// addDNSTargetResources adds resources which Route53 records may point at, if Route53 is audited
+func (r *Runner) addDNSTargetResources() {
+	selected := map[string]bool{}
+	for _, resource := range r.Builder.Config.Resources {
+		selected[resource.Name] = true
+	}
+
+	if !selected[constants.Route53ResourceName] {
+		return
+	}
+
+	for _, name := range constants.DNSTargetResources {
+		if !selected[name] {
+			logrus.Debugf("resource is added to look up targets of Route53 records: %s", name)
+			r.Builder.Config.Resources = append(r.Builder.Config.Resources, schema.Resource{
+				Name:   name,
+				Global: constants.ResourceGlobal[name],
+			})
+		}
+	}
+}
+
+// addRuleResources adds resources which are needed to evaluate rules
+func (r *Runner) addRuleResources(rules []audit.Rule) {
+	selected := map[string]bool{}
+	for _, resource := range r.Builder.Config.Resources {
+		selected[resource.Name] = true
 	}
-	logrus.Debug("Printer is successfully created")
 
-	logrus.Debugf("Set a number of data for printer: %d", len(result.Resources))
-	pr, err := printer.SetData(result.Provider, result.Resources)
+	for _, rule := range rules {
+		name := audit.ScannedResource(rule.Resource)
+		if !selected[name] {
+			logrus.Debugf("resource is added to evaluate rules: %s", name)
+			r.Builder.Config.Resources = append(r.Builder.Config.Resources, schema.Resource{
+				Name:   name,
+				Global: constants.ResourceGlobal[name],
+			})
+			selected[name] = true
+		}
+	}
+}
+
+// scan creates a client and scans resources of the account
+func scan(prov provider.Provider, account, roleArn, region, resource string, regionScoped bool) Record {
+	re := Record{
+		Error:     nil,
+		Account:   account,
+		AccountID: getAccountID(roleArn),
+		Resource:  resource,
+		Region:    region,
+	}
+
+	c, err := prov.CreateClient(roleArn, region, resource)
 	if err != nil {
-		return err
+		re.Error = err
+		return re
 	}
-	logrus.Debug("Data setting for printer is done")
 
-	logrus.Debug("Start printer to print the result")
-	if err := pr.Print(); err != nil {
-		return err
+	if sc, ok := c.(client.RegionScopedClient); ok && regionScoped {
+		sc.ScopeToRegion()
 	}
 
-	if len(errors) > 0 && (logrus.GetLevel() == logrus.DebugLevel && logrus.GetLevel() == logrus.TraceLevel) {
-		for _, err := range errors {
-			logrus.Error(err.Error())
+	data, err := c.Scan()
+	re.Error = err
+	re.Data = data
+
+	return re
+}
+
+// getAccounts returns accounts to scan.
+// Accounts discovered with AWS Organizations are added to accounts in configuration.
+// Current credentials are used if no account is specified
+func (r Runner) getAccounts() ([]schema.Account, error) {
+	accounts := r.Builder.Config.Accounts
+
+	if r.Builder.Config.Organization != nil {
+		logrus.Debug("discover accounts with organization")
+		discovered, err := client.DiscoverAccounts(*r.Builder.Config.Organization)
+		if err != nil {
+			return nil, err
 		}
+
+		accounts = mergeAccounts(accounts, discovered)
 	}
 
-	end := time.Now()
-	logrus.Infof("Scan time: %f sec", end.Sub(t).Seconds())
+	if len(accounts) == 0 {
+		return []schema.Account{{}}, nil
+	}
 
-	return nil
+	return accounts, nil
+}
+
+// getAccountName returns name of account for printing
+func getAccountName(account schema.Account) string {
+	if len(account.Name) > 0 {
+		return account.Name
+	}
+
+	return account.RoleArn
+}
+
+// getAccountID returns ID of account in the role ARN, or the role ARN if it is not a valid ARN
+func getAccountID(roleArn string) string {
+	if parsed, err := arn.Parse(roleArn); err == nil && len(parsed.AccountID) > 0 {
+		return parsed.AccountID
+	}
+
+	return roleArn
+}
+
+// mergeAccounts adds discovered accounts which are not configured.
+// Accounts are compared with IDs, because roles and names of the same account may differ
+func mergeAccounts(configured, discovered []schema.Account) []schema.Account {
+	ids := map[string]bool{}
+	for _, account := range configured {
+		ids[getAccountID(account.RoleArn)] = true
+	}
+
+	ret := append([]schema.Account{}, configured...)
+	for _, account := range discovered {
+		id := getAccountID(account.RoleArn)
+		if !ids[id] {
+			ret = append(ret, account)
+			ids[id] = true
+		}
+	}
+	return ret
 }
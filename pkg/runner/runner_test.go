@@ -0,0 +1,52 @@
+/*
+Copyright 2020 The redhawk Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runner
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/DevopsArtFactory/redhawk/pkg/schema"
+)
+
+func TestGetAccountID(t *testing.T) {
+	if id := getAccountID("arn:aws:iam::111111111111:role/audit"); id != "111111111111" {
+		t.Errorf("expected account ID, got %q", id)
+	}
+	if id := getAccountID("not-an-arn"); id != "not-an-arn" {
+		t.Errorf("expected raw value for invalid ARN, got %q", id)
+	}
+}
+
+func TestMergeAccounts(t *testing.T) {
+	configured := []schema.Account{
+		{Name: "prod", RoleArn: "arn:aws:iam::111111111111:role/audit"},
+	}
+	discovered := []schema.Account{
+		{Name: "production", RoleArn: "arn:aws:iam::111111111111:role/OrganizationAccountAccessRole"},
+		{Name: "prod", RoleArn: "arn:aws:iam::222222222222:role/OrganizationAccountAccessRole"},
+		{Name: "prod-copy", RoleArn: "arn:aws:iam::222222222222:role/other"},
+	}
+
+	expected := []schema.Account{
+		{Name: "prod", RoleArn: "arn:aws:iam::111111111111:role/audit"},
+		{Name: "prod", RoleArn: "arn:aws:iam::222222222222:role/OrganizationAccountAccessRole"},
+	}
+	if got := mergeAccounts(configured, discovered); !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+}
@@ -24,11 +24,20 @@ type Config struct {
 	// Multi accounts name and role for AWS Provider
 	Accounts []Account `yaml:"accounts,omitempty"`
 
+	// Account discovery with AWS Organizations. Discovered accounts are scanned with `accounts`
+	Organization *Organization `yaml:"organization,omitempty"`
+
 	// List of regions. Default region of provider will be applied if no region specified
 	Regions []string `yaml:"regions,omitempty"`
 
 	// List of resources. All resources will be applied if no resources specified
 	Resources []Resource `yaml:"resources,omitempty"`
+
+	// Configuration of rules for `redhawk audit`
+	Audit *Audit `yaml:"audit,omitempty"`
+
+	// Policies of required tags for `redhawk audit`
+	TagPolicy []TagPolicy `yaml:"tag_policy,omitempty"`
 }
 
 // Configuration for assume account for AWS
@@ -40,6 +49,17 @@ type Account struct {
 	RoleArn string `yaml:"role_arn,omitempty"`
 }
 
+// Configuration for discovering member accounts with AWS Organizations
+type Organization struct {
+	// Role ARN template for member accounts. `{{id}}` is replaced with the account ID
+	// For example: `arn:aws:iam::{{id}}:role/RedhawkAudit`
+	RoleTemplate string `yaml:"role_template"`
+
+	// List of organizational unit IDs to scan. Accounts in child OUs are also included.
+	// All accounts in the organization will be applied if no OU specified
+	OrganizationalUnits []string `yaml:"organizational_units,omitempty"`
+}
+
 // Resource configuration with detailed conditions
 type Resource struct {
 	// Resource name
@@ -48,3 +68,56 @@ type Resource struct {
 	// Whether or not it is a global resource or not
 	Global bool `yaml:"global"`
 }
+
+// Audit configuration for rules of findings
+type Audit struct {
+	// List of YAML files with custom rules. A custom rule replaces the built-in rule with the same ID
+	RuleFiles []string `yaml:"rule_files,omitempty"`
+
+	// Parameters applied to all rules which have them. For example: `max_inactive_days: 60`
+	Params map[string]string `yaml:"params,omitempty"`
+
+	// List of overrides of rules
+	Rules []RuleOverride `yaml:"rules,omitempty"`
+}
+
+// Override of a built-in or custom rule
+type RuleOverride struct {
+	// Rule ID to override
+	ID string `yaml:"id"`
+
+	// Disable the rule
+	Disabled bool `yaml:"disabled,omitempty"`
+
+	// Severity of findings from the rule.
+	// Valid severities are `info`, `low`, `medium`, `high` and `critical`
+	Severity string `yaml:"severity,omitempty"`
+
+	// Parameters of the rule. For example: `max_access_key_age: 60`
+	Params map[string]string `yaml:"params,omitempty"`
+}
+
+// Policy of tags which resources must have
+type TagPolicy struct {
+	// Resource types which the policy applies to, like `ec2` and `s3`.
+	// All resources with tags will be applied if no resources specified
+	Resources []string `yaml:"resources,omitempty"`
+
+	// Required tags
+	Tags []RequiredTag `yaml:"tags"`
+
+	// Severity of findings. Default severity is `low`
+	Severity string `yaml:"severity,omitempty"`
+}
+
+// Tag which resources must have
+type RequiredTag struct {
+	// Tag key
+	Key string `yaml:"key"`
+
+	// Allowed values of the tag. Any value is allowed if no values and pattern specified
+	Values []string `yaml:"values,omitempty"`
+
+	// Regular expression which values of the tag must match
+	Pattern string `yaml:"pattern,omitempty"`
+}
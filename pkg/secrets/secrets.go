@@ -0,0 +1,199 @@
+/*
+Copyright 2020 The redhawk Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secrets
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"io/ioutil"
+	"math"
+	"regexp"
+	"strings"
+
+	"github.com/DevopsArtFactory/redhawk/pkg/constants"
+)
+
+// Names of detectors
+const (
+	AWSAccessKeyID     = "aws_access_key_id"
+	AWSSecretAccessKey = "aws_secret_access_key"
+	PrivateKey         = "private_key"
+	PasswordInURL      = "password_in_url"
+	HighEntropy        = "high_entropy"
+)
+
+const (
+	// minEntropyLength is the minimum length of high-entropy strings
+	minEntropyLength = 20
+
+	// minEntropy is the minimum Shannon entropy per character of high-entropy strings.
+	// Random base64 strings of 24 characters or longer are above it, and words or hex strings are below 4
+	minEntropy = 4.3
+)
+
+// detector finds secrets in a line. The secret is the last group of the pattern
+type detector struct {
+	name    string
+	pattern *regexp.Regexp
+}
+
+var detectors = []detector{
+	{AWSAccessKeyID, regexp.MustCompile(`\b((?:AKIA|ASIA)[0-9A-Z]{16})\b`)},
+	{AWSSecretAccessKey, regexp.MustCompile(`(?i)(?:aws|secret).{0,20}?[=:"'\s]\s*["']?([A-Za-z0-9/+]{40})(?:[^A-Za-z0-9/+=]|$)`)},
+	{PrivateKey, regexp.MustCompile(`(-----BEGIN (?:RSA |EC |DSA |OPENSSH |ENCRYPTED |PGP )?PRIVATE KEY(?: BLOCK)?-----)`)},
+	{PasswordInURL, regexp.MustCompile(`[a-zA-Z][a-zA-Z0-9+.-]*://[^/\s:@"']+:([^/\s@"']+)@`)},
+}
+
+// valuePattern matches values of assignments or quoted strings, which are candidates of high-entropy strings
+var valuePattern = regexp.MustCompile(`(?:[=:]\s*["']?|["'])([A-Za-z0-9+/_.\-]{20,}={0,2})`)
+
+// separatorPattern splits values into words of paths, names and prefixed tokens
+var separatorPattern = regexp.MustCompile(`[/_.\-]+`)
+
+// Match is a secret found in text
+type Match struct {
+	Detector string
+	Line     int
+	Redacted string
+}
+
+// Detect returns secrets in the text. Gzip-compressed text such as user data is decompressed first
+func Detect(text []byte) []Match {
+	text = decompress(text)
+
+	var matches []Match
+	scanner := bufio.NewScanner(bytes.NewReader(text))
+	scanner.Buffer(make([]byte, 0, 64*1024), len(text)+1)
+
+	line := 0
+	for scanner.Scan() {
+		line++
+		for _, m := range DetectLine(scanner.Text()) {
+			m.Line = line
+			matches = append(matches, m)
+		}
+	}
+
+	return matches
+}
+
+// DetectLine returns secrets in a line. A string found by a detector is not reported as a high-entropy string again
+func DetectLine(line string) []Match {
+	var matches []Match
+	found := map[string]bool{}
+	for _, d := range detectors {
+		for _, sub := range d.pattern.FindAllStringSubmatch(line, -1) {
+			secret := sub[len(sub)-1]
+			if found[secret] {
+				continue
+			}
+			found[secret] = true
+
+			matches = append(matches, Match{
+				Detector: d.name,
+				Redacted: Redact(secret),
+			})
+		}
+	}
+
+	for _, sub := range valuePattern.FindAllStringSubmatch(line, -1) {
+		token := sub[1]
+		if isCovered(token, found) || !isHighEntropy(token) {
+			continue
+		}
+		found[token] = true
+
+		matches = append(matches, Match{
+			Detector: HighEntropy,
+			Redacted: Redact(token),
+		})
+	}
+
+	return matches
+}
+
+// Redact keeps only the first characters of the secret
+func Redact(secret string) string {
+	if len(secret) <= 8 {
+		return "****"
+	}
+	return secret[:4] + "****"
+}
+
+// isCovered checks if the token is a part of secrets or contains them
+func isCovered(token string, found map[string]bool) bool {
+	for secret := range found {
+		if strings.Contains(secret, token) || strings.Contains(token, secret) {
+			return true
+		}
+	}
+	return false
+}
+
+// isHighEntropy checks if the longest word of the token looks random.
+// Words without both letters and digits are skipped
+func isHighEntropy(token string) bool {
+	word := constants.EmptyString
+	for _, w := range separatorPattern.Split(strings.TrimRight(token, "="), -1) {
+		if len(w) > len(word) {
+			word = w
+		}
+	}
+
+	if len(word) < minEntropyLength || !strings.ContainsAny(word, "0123456789") ||
+		strings.ToLower(word) == word && strings.ToUpper(word) == word {
+		return false
+	}
+
+	return Entropy(word) >= minEntropy
+}
+
+// Entropy returns Shannon entropy per character of the string
+func Entropy(s string) float64 {
+	counts := map[rune]int{}
+	for _, r := range s {
+		counts[r]++
+	}
+
+	var entropy float64
+	n := float64(len(s))
+	for _, c := range counts {
+		p := float64(c) / n
+		entropy -= p * math.Log2(p)
+	}
+	return entropy
+}
+
+// decompress returns decompressed text if it is gzip-compressed
+func decompress(text []byte) []byte {
+	if len(text) < 2 || text[0] != 0x1f || text[1] != 0x8b {
+		return text
+	}
+
+	r, err := gzip.NewReader(bytes.NewReader(text))
+	if err != nil {
+		return text
+	}
+	defer r.Close()
+
+	b, err := ioutil.ReadAll(r)
+	if err != nil {
+		return text
+	}
+	return b
+}
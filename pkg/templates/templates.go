@@ -24,14 +24,17 @@ var (
 
 // AWSTemplate is a template for aws provider
 const AWSTemplate = `PROVIDER: {{ .Provider }}
+{{- if .Account }}
+ACCOUNT: {{ .Account }}
+{{- end }}
 {{- range $key, $val := .Summary }}
   {{- if eq $key "ec2" }}
     {{- if gt (len $val) 0 }}
 	  {{- if $.Detail }}
 ==============================================
-SERVICE	STATUS	NAME	ID	TYPE	AZ	Region	SG_NAME	SG_ID	SUBNET_ID	PUBLIC_IP	PRIVATE_IP	IMAGE	VPC_ID	KEY	LAUNCHED
+SERVICE	STATUS	NAME	ID	TYPE	AZ	Region	SG_NAME	SG_ID	SUBNET_ID	PUBLIC_IP	PRIVATE_IP	IMAGE	VPC_ID	KEY	IAM_PROFILE	IMDS_TOKENS	UNENCRYPTED_VOLUMES	LAUNCHED	STOPPED	TAGS
 	    {{- range $ec2 := $val }}
-EC2	{{ format $ec2.InstanceStatus }}	{{ format $ec2.Name }}	{{ format $ec2.InstanceID }}	{{ format $ec2.InstanceType }}	{{ format $ec2.AvailabilityZone }}	{{ format $ec2.RegionName }}	{{ format $ec2.SecurityGroupNames }}	{{ format $ec2.SecurityGroupIDs }}	{{ format $ec2.SubnetID }}	{{ format $ec2.PublicIP }}	{{ format $ec2.PrivateIPs }}	{{ format $ec2.ImageID }}	{{ format $ec2.VpcID }}	{{ format $ec2.KeyName }}	{{ format $ec2.LaunchTime }}
+EC2	{{ format $ec2.InstanceStatus }}	{{ format $ec2.Name }}	{{ format $ec2.InstanceID }}	{{ format $ec2.InstanceType }}	{{ format $ec2.AvailabilityZone }}	{{ format $ec2.RegionName }}	{{ format $ec2.SecurityGroupNames }}	{{ format $ec2.SecurityGroupIDs }}	{{ format $ec2.SubnetID }}	{{ format $ec2.PublicIP }}	{{ format $ec2.PrivateIPs }}	{{ format $ec2.ImageID }}	{{ format $ec2.VpcID }}	{{ format $ec2.KeyName }}	{{ format $ec2.IAMInstanceProfile }}	{{ format $ec2.MetadataHTTPTokens }}	{{ format $ec2.UnencryptedVolumes }}	{{ format $ec2.LaunchTime }}	{{ format $ec2.StoppedAt }}	{{ $ec2.Tags }}
 	    {{- end }}
 	  {{- else }}
 ==============================================
@@ -47,9 +50,31 @@ EC2	{{ format $ec2.InstanceStatus }}	{{ format $ec2.Name }}	{{ format $ec2.Insta
     {{- if gt (len $val) 0 }}
 
 ==============================================
-SERVICE	NAME	ID	VPC	OWNER	INBOUND	OUTBOUND	DESCRIPTION
+SERVICE	NAME	ID	VPC	OWNER	INBOUND	OUTBOUND	USAGE	ATTACHED_TO	REFERENCED_BY	DESCRIPTION
 	  {{- range $sg := $val }}
-SG	{{ $sg.Name }}	{{ format $sg.ID }}	{{ format $sg.VpcID }}	{{ format $sg.Owner }}	{{ format $sg.InboundCount }}	{{ format $sg.OutboundCount }}	{{ format $sg.Description }}
+SG	{{ $sg.Name }}	{{ format $sg.ID }}	{{ format $sg.VpcID }}	{{ format $sg.Owner }}	{{ format $sg.InboundCount }}	{{ format $sg.OutboundCount }}	{{ format $sg.Usage }}	{{ format $sg.AttachedTo }}	{{ format $sg.ReferencedBy }}	{{ format $sg.Description }}
+	  {{- end }}
+    {{- end }}
+  {{- end }}
+
+  {{- if eq $key "security_group_rule" }}
+    {{- if gt (len $val) 0 }}
+
+==============================================
+SERVICE	GROUP_ID	GROUP_NAME	DIRECTION	PROTOCOL	PORT	SOURCE_TYPE	SOURCE	WORLD_OPEN	SENSITIVE_PORTS	DESCRIPTION
+	  {{- range $rule := $val }}
+SG_RULE	{{ format $rule.GroupID }}	{{ format $rule.GroupName }}	{{ format $rule.Direction }}	{{ format $rule.Protocol }}	{{ format $rule.PortRange }}	{{ format $rule.SourceType }}	{{ format $rule.Source }}	{{ format $rule.WorldOpen }}	{{ format $rule.SensitivePorts }}	{{ format $rule.Description }}
+	  {{- end }}
+    {{- end }}
+  {{- end }}
+
+  {{- if eq $key "exposure" }}
+    {{- if gt (len $val) 0 }}
+
+==============================================
+SERVICE	TYPE	ID	NAME	REGION	PUBLIC_ADDRESS	EXPOSED_PORTS	SENSITIVE_PORTS	SUBNETS	SECURITY_GROUPS
+	  {{- range $exposure := $val }}
+EXPOSURE	{{ format $exposure.TargetType }}	{{ format $exposure.TargetID }}	{{ format $exposure.Name }}	{{ format $exposure.Region }}	{{ format $exposure.PublicAddress }}	{{ format $exposure.ExposedPorts }}	{{ format $exposure.SensitivePorts }}	{{ format $exposure.Subnets }}	{{ format $exposure.SecurityGroups }}
 	  {{- end }}
     {{- end }}
   {{- end }}
@@ -68,10 +93,18 @@ Route53	{{ $route53.Name }}	{{ format $route53.Type }}	{{ format $route53.Alias
   {{- if eq $key "s3" }}
     {{- if gt (len $val) 0 }}
 
+	  {{- if $.Detail }}
+==============================================
+SERVICE	NAME	REGION	ACCESS	ACCOUNT_BPA	BUCKET_BPA	PUBLIC_ACL_GRANTS	PUBLIC_POLICY	CROSS_ACCOUNTS	ENCRYPTION	KMS_KEY	VERSIONING	MFA_DELETE	OBJECT_LOCK	TLS_ENFORCED	LOGGING_ENABLED	LOGGING_BUCKET	CLOUDTRAIL_BUCKET	CREATED
+	    {{- range $s3 := $val }}
+S3	{{ $s3.Bucket }}	{{ format $s3.Region }}	{{ format $s3.Access }}	{{ format $s3.AccountBlockPublicAccess }}	{{ format $s3.BucketBlockPublicAccess }}	{{ format $s3.PublicACLGrants }}	{{ format $s3.PublicPolicy }}	{{ format $s3.CrossAccounts }}	{{ format $s3.Encryption }}	{{ format $s3.KmsKeyID }}	{{ format $s3.Versioning }}	{{ format $s3.MFADelete }}	{{ format $s3.ObjectLock }}	{{ format $s3.TLSEnforced }}	{{ format $s3.LoggingEnabled }}	{{ format $s3.LoggingBucket }}	{{ format $s3.CloudTrailBucket }}	{{ format $s3.Created }}
+	    {{- end }}
+	  {{- else }}
 ==============================================
-SERVICE	NAME	REGION	LOGGING_ENABLED	LOGGING_BUCKET	CREATED
-	  {{- range $s3 := $val }}
-S3	{{ $s3.Bucket }}	{{ format $s3.Region }}	{{ format $s3.LoggingEnabled }}	{{ format $s3.LoggingBucket }}	{{ format $s3.Created }}
+SERVICE	NAME	REGION	ACCESS	ENCRYPTION	VERSIONING	TLS_ENFORCED	LOGGING_ENABLED	CREATED
+	    {{- range $s3 := $val }}
+S3	{{ $s3.Bucket }}	{{ format $s3.Region }}	{{ format $s3.Access }}	{{ format $s3.Encryption }}	{{ format $s3.Versioning }}	{{ format $s3.TLSEnforced }}	{{ format $s3.LoggingEnabled }}	{{ format $s3.Created }}
+	    {{- end }}
 	  {{- end }}
     {{- end }}
   {{- end }}
@@ -80,9 +113,9 @@ S3	{{ $s3.Bucket }}	{{ format $s3.Region }}	{{ format $s3.LoggingEnabled }}	{{ f
     {{- if gt (len $val) 0 }}
 	  {{- if $.Detail }}
 ==============================================
-SERVICE	IDENTIFIER	ROLE	ENGINE	VERSION	SIZE	STATUS	AZ	STORAGE	OPTION_GROUPS	PARAMETER_GROUPS	SUBNET_GROUP	CREATED
+SERVICE	IDENTIFIER	ROLE	ENGINE	VERSION	END_OF_SUPPORT	SIZE	STATUS	AZ	MULTI_AZ	STORAGE	ENCRYPTED	PUBLIC	BACKUP_DAYS	DELETION_PROTECTION	OPTION_GROUPS	PARAMETER_GROUPS	SUBNET_GROUP	CREATED
 	    {{- range $rds := $val }}
-RDS	{{ $rds.RDSIdentifier }}	{{ format $rds.Role }}	{{ format $rds.Engine }}	{{ format $rds.EngineVersion }}	{{ format $rds.Size }}	{{ format $rds.Status }}	{{ format $rds.AvailabilityZone }}	{{ format $rds.StorageType }}	{{ format $rds.OptionGroup }}	{{ format $rds.ParameterGroup }}	{{ format $rds.DBSubnet }}	{{ format $rds.Created }}
+RDS	{{ $rds.RDSIdentifier }}	{{ format $rds.Role }}	{{ format $rds.Engine }}	{{ format $rds.EngineVersion }}	{{ format $rds.EndOfSupport }}	{{ format $rds.Size }}	{{ format $rds.Status }}	{{ format $rds.AvailabilityZone }}	{{ format $rds.MultiAZ }}	{{ format $rds.StorageType }}	{{ format $rds.StorageEncrypted }}	{{ format $rds.PubliclyAccessible }}	{{ format $rds.BackupRetentionPeriod }}	{{ format $rds.DeletionProtection }}	{{ format $rds.OptionGroup }}	{{ format $rds.ParameterGroup }}	{{ format $rds.DBSubnet }}	{{ format $rds.Created }}
 	    {{- end }}
 	  {{- else }}
 ==============================================
@@ -95,11 +128,39 @@ RDS	{{ $rds.RDSIdentifier }}	{{ format $rds.Role }}	{{ format $rds.Engine }}	{{
   {{- end }}
 
   {{- if eq $key "iam_user" }}
+    {{- if gt (len $val) 0 }}
+	  {{- if $.Detail }}
+==============================================
+SERVICE	NAME	MFA	MFA_ACTIVE	GROUP_COUNT	PASSWORD_ENABLED	PASSWORD_AGE	CONSOLE_LAST_LOGIN	KEY1_ACTIVE	KEY1_AGE	KEY1_LAST_SERVICE	KEY2_ACTIVE	KEY2_AGE	KEY2_LAST_SERVICE	ACCESS_KEY_LAST_USED	LAST_ACTIVITY	CREATED
+	    {{- range $iamUser := $val }}
+IAM_USER	{{ $iamUser.UserName }}	{{ format $iamUser.MFA }}	{{ format $iamUser.MFAActive }}	{{ format $iamUser.GroupCount }}	{{ format $iamUser.PasswordEnabled }}	{{ format $iamUser.PasswordAge }}	{{ format $iamUser.ConsoleLastLogin }}	{{ format $iamUser.AccessKey1Active }}	{{ format $iamUser.AccessKey1Age }}	{{ format $iamUser.AccessKey1LastUsedService }}	{{ format $iamUser.AccessKey2Active }}	{{ format $iamUser.AccessKey2Age }}	{{ format $iamUser.AccessKey2LastUsedService }}	{{ format $iamUser.AccessKeyLastUsed }}	{{ format $iamUser.UserLastActivity }}	{{ format $iamUser.UserCreated }}
+	    {{- end }}
+	  {{- else }}
+==============================================
+SERVICE	NAME	MFA_ACTIVE	GROUP_COUNT	PASSWORD_ENABLED	ACCESS_KEY_AGE	ACCESS_KEY_LAST_USED	LAST_ACTIVITY	CREATED
+	    {{- range $iamUser := $val }}
+IAM_USER	{{ $iamUser.UserName }}	{{ format $iamUser.MFAActive }}	{{ format $iamUser.GroupCount }}	{{ format $iamUser.PasswordEnabled }}	{{ format $iamUser.AccessKeyAge }}	{{ format $iamUser.AccessKeyLastUsed }}	{{ format $iamUser.UserLastActivity }}	{{ format $iamUser.UserCreated }}
+	    {{- end }}
+	  {{- end }}
+    {{- end }}
+  {{- end }}
+
+  {{- if eq $key "iam_access_key" }}
     {{- if gt (len $val) 0 }}
 ==============================================
-SERVICE	NAME	MFA	GROUP_COUNT	ACCESS_KEY_LAST_USED	CREATED
-	  {{- range $iamUser := $val }}
-IAM_USER	{{ $iamUser.UserName }}	{{ format $iamUser.MFA }}	{{ format $iamUser.GroupCount }}	{{ format $iamUser.AccessKeyLastUsed }}	{{ format $iamUser.UserCreated }}
+SERVICE	ACCESS_KEY_ID	USER	STATUS	AGE_DAYS	LAST_USED	LAST_USED_SERVICE	LAST_USED_REGION	CREATED
+	  {{- range $accessKey := $val }}
+IAM_ACCESS_KEY	{{ format $accessKey.AccessKeyID }}	{{ format $accessKey.UserName }}	{{ format $accessKey.Status }}	{{ format $accessKey.AgeDays }}	{{ format $accessKey.LastUsed }}	{{ format $accessKey.LastUsedService }}	{{ format $accessKey.LastUsedRegion }}	{{ format $accessKey.Created }}
+	  {{- end }}
+    {{- end }}
+  {{- end }}
+
+  {{- if eq $key "iam_escalation" }}
+    {{- if gt (len $val) 0 }}
+==============================================
+SERVICE	TYPE	NAME	ADMIN	PRIMITIVES	PATH_COUNT	PATHS
+	  {{- range $escalation := $val }}
+IAM_ESCALATION	{{ format $escalation.PrincipalType }}	{{ format $escalation.PrincipalName }}	{{ format $escalation.AdminEquivalent }}	{{ format $escalation.Primitives }}	{{ format $escalation.PathCount }}	{{ format $escalation.Paths }}
 	  {{- end }}
     {{- end }}
   {{- end }}
@@ -116,13 +177,289 @@ IAM_GROUP	{{ $iamGroup.GroupName }}	{{ format $iamGroup.UserCount }}	{{ format $
 
   {{- if eq $key "iam_role" }}
     {{- if gt (len $val) 0 }}
+	  {{- if $.Detail }}
 ==============================================
-SERVICE	NAME	TRUST_ENTITIES	ROLE_LAST_ACTIVITY
-	  {{- range $iamRole := $val }}
-IAM_ROLE	{{ format $iamRole.RoleName }}	{{ format $iamRole.TrustedEntities }}	{{ format $iamRole.RoleLastActivity }}
+SERVICE	NAME	TRUST	TRUST_ENTITIES	TRUSTED_ACCOUNTS	EXTERNAL_ID	TRUST_CONDITIONS	ATTACHED_POLICIES	INLINE_POLICIES	ROLE_LAST_ACTIVITY	CREATED
+	    {{- range $iamRole := $val }}
+IAM_ROLE	{{ format $iamRole.RoleName }}	{{ format $iamRole.TrustClassification }}	{{ format $iamRole.TrustedEntities }}	{{ format $iamRole.TrustedAccounts }}	{{ format $iamRole.ExternalIDRequired }}	{{ format $iamRole.TrustConditions }}	{{ format $iamRole.AttachedPolicies }}	{{ format $iamRole.InlinePolicies }}	{{ format $iamRole.RoleLastActivity }}	{{ format $iamRole.Created }}
+	    {{- end }}
+	  {{- else }}
+==============================================
+SERVICE	NAME	TRUST	TRUST_ENTITIES	EXTERNAL_ID	ATTACHED_POLICIES	INLINE_POLICIES	ROLE_LAST_ACTIVITY
+	    {{- range $iamRole := $val }}
+IAM_ROLE	{{ format $iamRole.RoleName }}	{{ format $iamRole.TrustClassification }}	{{ format $iamRole.TrustedEntities }}	{{ format $iamRole.ExternalIDRequired }}	{{ format $iamRole.AttachedPolicies }}	{{ format $iamRole.InlinePolicies }}	{{ format $iamRole.RoleLastActivity }}
+	    {{- end }}
+	  {{- end }}
+    {{- end }}
+  {{- end }}
+
+  {{- if eq $key "iam_policy" }}
+    {{- if gt (len $val) 0 }}
+==============================================
+SERVICE	NAME	TYPE	VERSION	ATTACHMENT_COUNT	ATTACHED_ENTITIES	WILDCARD_ACTION	WILDCARD_RESOURCE	UPDATED
+	  {{- range $iamPolicy := $val }}
+IAM_POLICY	{{ format $iamPolicy.PolicyName }}	{{ format $iamPolicy.PolicyType }}	{{ format $iamPolicy.DefaultVersion }}	{{ format $iamPolicy.AttachmentCount }}	{{ format $iamPolicy.AttachedEntities }}	{{ format $iamPolicy.WildcardAction }}	{{ format $iamPolicy.WildcardResource }}	{{ format $iamPolicy.Updated }}
       {{- end }}
     {{- end }}
   {{- end }}
+
+  {{- if eq $key "account" }}
+    {{- if gt (len $val) 0 }}
+	  {{- if $.Detail }}
+==============================================
+SERVICE	ID	ALIAS	ROOT_MFA	ROOT_ACCESS_KEYS	PASSWORD_POLICY	MIN_LENGTH	SYMBOLS	NUMBERS	UPPERCASE	LOWERCASE	REUSE_PREVENTION	MAX_AGE	BILLING_CONTACT	OPERATIONS_CONTACT	SECURITY_CONTACT	MULTI_REGION_TRAIL
+	    {{- range $account := $val }}
+ACCOUNT	{{ format $account.AccountID }}	{{ format $account.AccountAlias }}	{{ format $account.RootMFAEnabled }}	{{ format $account.RootAccessKeysPresent }}	{{ format $account.PasswordPolicy }}	{{ format $account.MinimumPasswordLength }}	{{ format $account.RequireSymbols }}	{{ format $account.RequireNumbers }}	{{ format $account.RequireUppercase }}	{{ format $account.RequireLowercase }}	{{ format $account.PasswordReusePrevention }}	{{ format $account.MaxPasswordAge }}	{{ format $account.BillingContact }}	{{ format $account.OperationsContact }}	{{ format $account.SecurityContact }}	{{ format $account.MultiRegionTrail }}
+	    {{- end }}
+	  {{- else }}
+==============================================
+SERVICE	ID	ALIAS	ROOT_MFA	ROOT_ACCESS_KEYS	PASSWORD_POLICY	MIN_LENGTH	MAX_AGE	SECURITY_CONTACT
+	    {{- range $account := $val }}
+ACCOUNT	{{ format $account.AccountID }}	{{ format $account.AccountAlias }}	{{ format $account.RootMFAEnabled }}	{{ format $account.RootAccessKeysPresent }}	{{ format $account.PasswordPolicy }}	{{ format $account.MinimumPasswordLength }}	{{ format $account.MaxPasswordAge }}	{{ format $account.SecurityContact }}
+	    {{- end }}
+	  {{- end }}
+    {{- end }}
+  {{- end }}
+
+  {{- if eq $key "efs" }}
+    {{- if gt (len $val) 0 }}
+	  {{- if $.Detail }}
+==============================================
+SERVICE	NAME	ID	REGION	STATE	PERFORMANCE	THROUGHPUT	ENCRYPTED	KMS_KEY	SIZE	MOUNT_TARGETS	SUBNETS	ACCESS_POINTS	BACKUP	CREATED
+	    {{- range $efs := $val }}
+EFS	{{ format $efs.Name }}	{{ format $efs.FileSystemID }}	{{ format $efs.Region }}	{{ format $efs.LifeCycleState }}	{{ format $efs.PerformanceMode }}	{{ format $efs.ThroughputMode }}	{{ format $efs.Encrypted }}	{{ format $efs.KmsKeyID }}	{{ format $efs.SizeInBytes }}	{{ format $efs.MountTargetCount }}	{{ format $efs.MountTargetSubnets }}	{{ format $efs.AccessPointCount }}	{{ format $efs.BackupPolicy }}	{{ format $efs.Created }}
+	    {{- end }}
+	  {{- else }}
+==============================================
+SERVICE	NAME	ID	STATE	ENCRYPTED	MOUNT_TARGETS	ACCESS_POINTS	BACKUP	CREATED
+	    {{- range $efs := $val }}
+EFS	{{ format $efs.Name }}	{{ format $efs.FileSystemID }}	{{ format $efs.LifeCycleState }}	{{ format $efs.Encrypted }}	{{ format $efs.MountTargetCount }}	{{ format $efs.AccessPointCount }}	{{ format $efs.BackupPolicy }}	{{ format $efs.Created }}
+	    {{- end }}
+	  {{- end }}
+    {{- end }}
+  {{- end }}
+
+  {{- if eq $key "fsx" }}
+    {{- if gt (len $val) 0 }}
+==============================================
+SERVICE	ID	TYPE	REGION	STATE	STORAGE	CAPACITY	KMS_KEY	VPC_ID	BACKUP_RETENTION	CREATED
+	  {{- range $fsx := $val }}
+FSX	{{ format $fsx.FileSystemID }}	{{ format $fsx.FileSystemType }}	{{ format $fsx.Region }}	{{ format $fsx.Lifecycle }}	{{ format $fsx.StorageType }}	{{ format $fsx.StorageCapacity }}	{{ format $fsx.KmsKeyID }}	{{ format $fsx.VpcID }}	{{ format $fsx.BackupRetentionDays }}	{{ format $fsx.Created }}
+	  {{- end }}
+    {{- end }}
+  {{- end }}
+
+  {{- if eq $key "kinesis" }}
+    {{- if gt (len $val) 0 }}
+==============================================
+SERVICE	NAME	REGION	STATUS	MODE	ENCRYPTION	KMS_KEY	RETENTION_HOURS	OPEN_SHARDS	CREATED
+	  {{- range $kinesis := $val }}
+KINESIS	{{ format $kinesis.StreamName }}	{{ format $kinesis.Region }}	{{ format $kinesis.StreamStatus }}	{{ format $kinesis.StreamMode }}	{{ format $kinesis.EncryptionType }}	{{ format $kinesis.KmsKeyID }}	{{ format $kinesis.RetentionHours }}	{{ format $kinesis.OpenShardCount }}	{{ format $kinesis.Created }}
+	  {{- end }}
+    {{- end }}
+  {{- end }}
+
+  {{- if eq $key "msk" }}
+    {{- if gt (len $val) 0 }}
+==============================================
+SERVICE	NAME	REGION	STATE	VERSION	BROKERS	TYPE	AT_REST_KMS_KEY	CLIENT_BROKER	IN_CLUSTER	PUBLIC_ACCESS	RETENTION_HOURS	CREATED
+	  {{- range $msk := $val }}
+MSK	{{ format $msk.ClusterName }}	{{ format $msk.Region }}	{{ format $msk.State }}	{{ format $msk.KafkaVersion }}	{{ format $msk.BrokerCount }}	{{ format $msk.InstanceType }}	{{ format $msk.AtRestKmsKeyID }}	{{ format $msk.InTransitClientBroker }}	{{ format $msk.InTransitInCluster }}	{{ format $msk.PublicAccess }}	{{ format $msk.RetentionHours }}	{{ format $msk.Created }}
+	  {{- end }}
+    {{- end }}
+  {{- end }}
+
+  {{- if eq $key "eip" }}
+    {{- if gt (len $val) 0 }}
+
+==============================================
+SERVICE	PUBLIC_IP	ALLOCATION_ID	REGION	DOMAIN	INSTANCE_ID	NETWORK_INTERFACE_ID
+	  {{- range $eip := $val }}
+EIP	{{ format $eip.PublicIP }}	{{ format $eip.AllocationID }}	{{ format $eip.Region }}	{{ format $eip.Domain }}	{{ format $eip.InstanceID }}	{{ format $eip.NetworkInterfaceID }}
+	  {{- end }}
+    {{- end }}
+  {{- end }}
+
+  {{- if eq $key "secret" }}
+    {{- if gt (len $val) 0 }}
+
+==============================================
+SERVICE	SOURCE	RESOURCE_ID	REGION	LOCATION	DETECTOR	SECRET
+	  {{- range $sr := $val }}
+SECRET	{{ format $sr.Source }}	{{ format $sr.ResourceID }}	{{ format $sr.Region }}	{{ format $sr.Location }}	{{ format $sr.Detector }}	{{ format $sr.Secret }}
+	  {{- end }}
+    {{- end }}
+  {{- end }}
+
+  {{- if eq $key "key_pair" }}
+    {{- if gt (len $val) 0 }}
+
+==============================================
+SERVICE	KEY_NAME	KEY_PAIR_ID	REGION	FINGERPRINT	INSTANCES
+	  {{- range $kp := $val }}
+KEY_PAIR	{{ format $kp.KeyName }}	{{ format $kp.KeyPairID }}	{{ format $kp.Region }}	{{ format $kp.Fingerprint }}	{{ format $kp.Instances }}
+	  {{- end }}
+    {{- end }}
+  {{- end }}
+
+  {{- if eq $key "load_balancer" }}
+    {{- if gt (len $val) 0 }}
+
+==============================================
+SERVICE	NAME	TYPE	SCHEME	DNS_NAME	REGION	VPC_ID	STATE	ACCESS_LOGS	ACCESS_LOGS_BUCKET	CREATED
+	  {{- range $lb := $val }}
+LOAD_BALANCER	{{ format $lb.Name }}	{{ format $lb.Type }}	{{ format $lb.Scheme }}	{{ format $lb.DNSName }}	{{ format $lb.Region }}	{{ format $lb.VpcID }}	{{ format $lb.State }}	{{ format $lb.AccessLogsEnabled }}	{{ format $lb.AccessLogsBucket }}	{{ format $lb.Created }}
+	  {{- end }}
+    {{- end }}
+  {{- end }}
+
+  {{- if eq $key "cloudtrail" }}
+    {{- if gt (len $val) 0 }}
+	  {{- if $.Detail }}
+==============================================
+SERVICE	NAME	REGION	MULTI_REGION	ORGANIZATION	LOGGING	LOG_FILE_VALIDATION	S3_BUCKET	CLOUDWATCH_LOGS_LOG_GROUP	LATEST_CLOUDWATCH_LOGS_DELIVERY	KMS_KEY
+	    {{- range $ct := $val }}
+CLOUDTRAIL	{{ format $ct.Name }}	{{ format $ct.Region }}	{{ format $ct.MultiRegion }}	{{ format $ct.OrganizationTrail }}	{{ format $ct.Logging }}	{{ format $ct.LogFileValidation }}	{{ format $ct.S3Bucket }}	{{ format $ct.CloudWatchLogsLogGroup }}	{{ format $ct.LatestCloudWatchLogsDelivery }}	{{ format $ct.KmsKeyID }}
+	    {{- end }}
+	  {{- else }}
+==============================================
+SERVICE	NAME	REGION	MULTI_REGION	LOGGING	LOG_FILE_VALIDATION	S3_BUCKET	CLOUDWATCH_LOGS
+	    {{- range $ct := $val }}
+CLOUDTRAIL	{{ format $ct.Name }}	{{ format $ct.Region }}	{{ format $ct.MultiRegion }}	{{ format $ct.Logging }}	{{ format $ct.LogFileValidation }}	{{ format $ct.S3Bucket }}	{{ format $ct.CloudWatchLogsLogGroup }}
+	    {{- end }}
+	  {{- end }}
+    {{- end }}
+  {{- end }}
+
+  {{- if eq $key "vpc" }}
+    {{- if gt (len $val) 0 }}
+
+==============================================
+SERVICE	VPC_ID	NAME	CIDR_BLOCK	DEFAULT	REGION	FLOW_LOGS_ENABLED	FLOW_LOGS
+	  {{- range $vpc := $val }}
+VPC	{{ format $vpc.VpcID }}	{{ format $vpc.Name }}	{{ format $vpc.CidrBlock }}	{{ format $vpc.Default }}	{{ format $vpc.Region }}	{{ format $vpc.FlowLogsEnabled }}	{{ format $vpc.FlowLogs }}
+	  {{- end }}
+    {{- end }}
+  {{- end }}
+
+  {{- if eq $key "cloudfront" }}
+    {{- if gt (len $val) 0 }}
+
+==============================================
+SERVICE	ID	DOMAIN_NAME	ALIASES	ORIGINS	STATUS	ENABLED	LAST_MODIFIED
+	  {{- range $cf := $val }}
+CLOUDFRONT	{{ format $cf.DistributionID }}	{{ format $cf.DomainName }}	{{ format $cf.Aliases }}	{{ format $cf.Origins }}	{{ format $cf.Status }}	{{ format $cf.Enabled }}	{{ format $cf.LastModified }}
+	  {{- end }}
+    {{- end }}
+  {{- end }}
+
+  {{- if eq $key "elastic_beanstalk" }}
+    {{- if gt (len $val) 0 }}
+
+==============================================
+SERVICE	APPLICATION	ENVIRONMENT	ID	REGION	CNAME	STATUS	HEALTH	CREATED
+	  {{- range $eb := $val }}
+ELASTIC_BEANSTALK	{{ format $eb.ApplicationName }}	{{ format $eb.EnvironmentName }}	{{ format $eb.EnvironmentID }}	{{ format $eb.Region }}	{{ format $eb.CNAME }}	{{ format $eb.Status }}	{{ format $eb.Health }}	{{ format $eb.Created }}
+	  {{- end }}
+    {{- end }}
+  {{- end }}
+
+  {{- if eq $key "organization_account" }}
+    {{- if gt (len $val) 0 }}
+==============================================
+SERVICE	ID	NAME	EMAIL	STATUS	JOINED_METHOD	PARENT_ID	PARENT_NAME	SCPS	JOINED
+	  {{- range $account := $val }}
+ORG_ACCOUNT	{{ format $account.AccountID }}	{{ format $account.Name }}	{{ format $account.Email }}	{{ format $account.Status }}	{{ format $account.JoinedMethod }}	{{ format $account.ParentID }}	{{ format $account.ParentName }}	{{ format $account.SCPs }}	{{ format $account.Joined }}
+	  {{- end }}
+    {{- end }}
+  {{- end }}
+
+  {{- if eq $key "organization_unit" }}
+    {{- if gt (len $val) 0 }}
+==============================================
+SERVICE	ID	NAME	PARENT_ID	ACCOUNT_COUNT	SCPS
+	  {{- range $ou := $val }}
+ORG_UNIT	{{ format $ou.OUID }}	{{ format $ou.Name }}	{{ format $ou.ParentID }}	{{ format $ou.AccountCount }}	{{ format $ou.SCPs }}
+	  {{- end }}
+    {{- end }}
+  {{- end }}
+
+  {{- if eq $key "organization_policy" }}
+    {{- if gt (len $val) 0 }}
+==============================================
+SERVICE	ID	NAME	AWS_MANAGED	TARGETS	DESCRIPTION
+	  {{- range $policy := $val }}
+ORG_POLICY	{{ format $policy.PolicyID }}	{{ format $policy.Name }}	{{ format $policy.AWSManaged }}	{{ format $policy.Targets }}	{{ format $policy.Description }}
+	  {{- end }}
+    {{- end }}
+  {{- end }}
+
+  {{- if eq $key "sso_permission_set" }}
+    {{- if gt (len $val) 0 }}
+	  {{- if $.Detail }}
+==============================================
+SERVICE	NAME	REGION	SESSION_DURATION	MANAGED_POLICIES	CUSTOMER_MANAGED_POLICIES	INLINE_POLICY	ACCOUNT_COUNT	ACCOUNTS	CREATED
+	    {{- range $ps := $val }}
+SSO_PERMISSION_SET	{{ format $ps.Name }}	{{ format $ps.Region }}	{{ format $ps.SessionDuration }}	{{ format $ps.ManagedPolicies }}	{{ format $ps.CustomerManagedPolicies }}	{{ format $ps.InlinePolicy }}	{{ format $ps.AccountCount }}	{{ format $ps.Accounts }}	{{ format $ps.Created }}
+	    {{- end }}
+	  {{- else }}
+==============================================
+SERVICE	NAME	MANAGED_POLICIES	CUSTOMER_MANAGED_POLICIES	INLINE_POLICY	ACCOUNT_COUNT
+	    {{- range $ps := $val }}
+SSO_PERMISSION_SET	{{ format $ps.Name }}	{{ format $ps.ManagedPolicies }}	{{ format $ps.CustomerManagedPolicies }}	{{ format $ps.InlinePolicy }}	{{ format $ps.AccountCount }}
+	    {{- end }}
+	  {{- end }}
+    {{- end }}
+  {{- end }}
+
+  {{- if eq $key "sso_account_assignment" }}
+    {{- if gt (len $val) 0 }}
+==============================================
+SERVICE	PERMISSION_SET	ACCOUNT_ID	PRINCIPAL_TYPE	PRINCIPAL_NAME	PRINCIPAL_ID
+	  {{- range $as := $val }}
+SSO_ASSIGNMENT	{{ format $as.PermissionSet }}	{{ format $as.AccountID }}	{{ format $as.PrincipalType }}	{{ format $as.PrincipalName }}	{{ format $as.PrincipalID }}
+	  {{- end }}
+    {{- end }}
+  {{- end }}
+{{- end }}
+`
+
+// FindingTemplate is a template for findings of audit
+const FindingTemplate = `PROVIDER: {{ .Provider }}
+{{- if .Framework }}
+FRAMEWORK: {{ .Framework }}
+{{- if .ByControl }}
+==============================================
+CONTROL	SEVERITY	RULE	ACCOUNT	REGION	RESOURCE	ID	MESSAGE
+  {{- range $f := .ByControl }}
+{{ $f.Control }}	{{ $f.Severity }}	{{ $f.RuleID }}	{{ if $f.Account }}{{ $f.Account }}{{ else }}-{{ end }}	{{ $f.Region }}	{{ $f.ResourceType }}	{{ $f.ResourceID }}	{{ $f.Message }}
+  {{- end }}
+{{- end }}
+{{- else if .Findings }}
+==============================================
+SEVERITY	RULE	ACCOUNT	REGION	RESOURCE	ID	MESSAGE
+  {{- range $f := .Findings }}
+{{ $f.Severity }}	{{ $f.RuleID }}	{{ if $f.Account }}{{ $f.Account }}{{ else }}-{{ end }}	{{ $f.Region }}	{{ $f.ResourceType }}	{{ $f.ResourceID }}	{{ $f.Message }}
+  {{- end }}
+{{- end }}
+{{- if .Controls }}
+==============================================
+BENCHMARK: {{ .Benchmark }}
+CONTROL	STATUS	FINDINGS	TITLE	NOTE
+  {{- range $c := .Controls }}
+{{ $c.ID }}	{{ $c.Status }}	{{ $c.Findings }}	{{ $c.Title }}	{{ $c.Note }}
+  {{- end }}
+==============================================
+CONTROLS: pass {{ index .ControlSummary "pass" }}, fail {{ index .ControlSummary "fail" }}, not_evaluated {{ index .ControlSummary "not_evaluated" }}
+{{- end }}
+==============================================
+{{- range $s := .Summary }}
+{{ $s.Severity }}:	{{ $s.Count }}
+{{- end }}
+==============================================
+TOTAL FINDINGS: {{ len .Findings }}
+{{- if .Suppressed }}
+SUPPRESSED FINDINGS: {{ .Suppressed }}
 {{- end }}
 `
 
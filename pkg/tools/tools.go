@@ -22,6 +22,7 @@ import (
 	"net/url"
 	"os"
 	"reflect"
+	"regexp"
 	"time"
 
 	"github.com/sirupsen/logrus"
@@ -101,6 +102,14 @@ func DecodeURLEncodedString(encoded string) (string, error) {
 	return decoded, nil
 }
 
+// invalidFileNameChars matches characters which are not safe for file name
+var invalidFileNameChars = regexp.MustCompile(`[^a-zA-Z0-9._-]+`)
+
+// SanitizeFileName replaces characters which are not safe for file name
+func SanitizeFileName(name string) string {
+	return invalidFileNameChars.ReplaceAllString(name, "_")
+}
+
 // Formatting removes nil value
 func Formatting(i interface{}) interface{} {
 	switch reflect.TypeOf(i).String() {
@@ -121,6 +130,10 @@ func Formatting(i interface{}) interface{} {
 		if i.(*int64) == nil {
 			return "-"
 		}
+	case "*bool":
+		if i.(*bool) == nil {
+			return "-"
+		}
 	}
 
 	return i